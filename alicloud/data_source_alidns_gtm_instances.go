@@ -0,0 +1,495 @@
+package alicloud
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	alicloudBaseClient "github.com/alibabacloud-go/bssopenapi-20171214/v3/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+// alidnsGtmInstancesQueryBatchSize is the maximum number of the GTM instance
+// IDs queried from BSS at a time.
+const alidnsGtmInstancesQueryBatchSize = 100
+
+var (
+	_ datasource.DataSource              = &alidnsGtmInstancesDataSource{}
+	_ datasource.DataSourceWithConfigure = &alidnsGtmInstancesDataSource{}
+)
+
+func NewAlidnsGtmInstancesDataSource() datasource.DataSource {
+	return &alidnsGtmInstancesDataSource{}
+}
+
+type alidnsGtmInstancesDataSource struct {
+	baseClient *alicloudBaseClient.Client
+	client     *alicloudDnsClient.Client
+}
+
+type alidnsGtmInstancesDataSourceModel struct {
+	NameRegex       types.String                 `tfsdk:"name_regex"`
+	ResourceGroupId types.String                 `tfsdk:"resource_group_id"`
+	PackageEdition  types.String                 `tfsdk:"package_edition"`
+	Instances       []*alidnsGtmInstancesElement `tfsdk:"instances"`
+}
+
+type alidnsGtmInstancesElement struct {
+	Id                           types.String `tfsdk:"id"`
+	InstanceName                 types.String `tfsdk:"instance_name"`
+	InstanceType                 types.String `tfsdk:"instance_type"`
+	PackageEdition               types.String `tfsdk:"package_edition"`
+	PaymentType                  types.String `tfsdk:"payment_type"`
+	ResourceGroupId              types.String `tfsdk:"resource_group_id"`
+	StrategyMode                 types.String `tfsdk:"strategy_mode"`
+	Ttl                          types.Int64  `tfsdk:"ttl"`
+	Cname                        types.String `tfsdk:"cname"`
+	PublicUserDomainName         types.String `tfsdk:"public_user_domain_name"`
+	CreateTime                   types.String `tfsdk:"create_time"`
+	ExpireTime                   types.String `tfsdk:"expire_time"`
+	ExpireTimestamp              types.Int64  `tfsdk:"expire_timestamp"`
+	RenewalStatus                types.String `tfsdk:"renewal_status"`
+	RenewPeriod                  types.Int64  `tfsdk:"renew_period"`
+	HealthCheckTaskCount         types.Int64  `tfsdk:"health_check_task_count"`
+	HealthCheckTaskUsedCount     types.Int64  `tfsdk:"health_check_task_used_count"`
+	AddressPoolCount             types.Int64  `tfsdk:"address_pool_count"`
+	AccessStrategyCount          types.Int64  `tfsdk:"access_strategy_count"`
+	AddrAvailableNum             types.Int64  `tfsdk:"addr_available_num"`
+	AddrNotAvailableNum          types.Int64  `tfsdk:"addr_not_available_num"`
+	AddrPoolGroupNotAvailableNum types.Int64  `tfsdk:"addr_pool_group_not_available_num"`
+	StrategyNotAvailableNum      types.Int64  `tfsdk:"strategy_not_available_num"`
+	SwitchToFailoverStrategyNum  types.Int64  `tfsdk:"switch_to_failover_strategy_num"`
+}
+
+func (d *alidnsGtmInstancesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_gtm_instances"
+}
+
+func (d *alidnsGtmInstancesDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source provides the Global Traffic Manager instances of the current AliCloud user, " +
+			"with their expiry, renewal and health status.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Description: "A regex to filter the instances by name.",
+				Optional:    true,
+			},
+			"resource_group_id": schema.StringAttribute{
+				Description: "The ID of the resource group to filter the instances.",
+				Optional:    true,
+			},
+			"package_edition": schema.StringAttribute{
+				Description: "The package version to filter the instances. Valid values: ultimate, standard.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("standard", "ultimate"),
+				},
+			},
+			"instances": schema.ListNestedAttribute{
+				Description: "A list of Global Traffic Manager instances.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the instance.",
+							Computed:    true,
+						},
+						"instance_name": schema.StringAttribute{
+							Description: "The name of the instance.",
+							Computed:    true,
+						},
+						"instance_type": schema.StringAttribute{
+							Description: "The type of the instance, cn or intl.",
+							Computed:    true,
+						},
+						"package_edition": schema.StringAttribute{
+							Description: "The package version of the instance.",
+							Computed:    true,
+						},
+						"payment_type": schema.StringAttribute{
+							Description: "The payment type of the instance.",
+							Computed:    true,
+						},
+						"resource_group_id": schema.StringAttribute{
+							Description: "The ID of the resource group of the instance.",
+							Computed:    true,
+						},
+						"strategy_mode": schema.StringAttribute{
+							Description: "The type of the access policy of the instance, GEO or LATENCY.",
+							Computed:    true,
+						},
+						"ttl": schema.Int64Attribute{
+							Description: "The global time to live of the instance.",
+							Computed:    true,
+						},
+						"cname": schema.StringAttribute{
+							Description: "The CNAME to access the instance, which the DNS records of the " +
+								"business domain point to.",
+							Computed: true,
+						},
+						"public_user_domain_name": schema.StringAttribute{
+							Description: "The business domain name of the instance.",
+							Computed:    true,
+						},
+						"create_time": schema.StringAttribute{
+							Description: "The time when the instance was created.",
+							Computed:    true,
+						},
+						"expire_time": schema.StringAttribute{
+							Description: "The time when the instance expires.",
+							Computed:    true,
+						},
+						"expire_timestamp": schema.Int64Attribute{
+							Description: "The time when the instance expires, in milliseconds since the epoch.",
+							Computed:    true,
+						},
+						"renewal_status": schema.StringAttribute{
+							Description: "The renewal status of the instance, AutoRenewal, ManualRenewal or NotRenewal.",
+							Computed:    true,
+						},
+						"renew_period": schema.Int64Attribute{
+							Description: "The automatic renewal period of the instance, the unit is month.",
+							Computed:    true,
+						},
+						"health_check_task_count": schema.Int64Attribute{
+							Description: "The quota of health check tasks of the instance.",
+							Computed:    true,
+						},
+						"health_check_task_used_count": schema.Int64Attribute{
+							Description: "The number of health check tasks in use.",
+							Computed:    true,
+						},
+						"address_pool_count": schema.Int64Attribute{
+							Description: "The number of address pools of the instance.",
+							Computed:    true,
+						},
+						"access_strategy_count": schema.Int64Attribute{
+							Description: "The number of access strategies of the instance.",
+							Computed:    true,
+						},
+						"addr_available_num": schema.Int64Attribute{
+							Description: "The number of available addresses.",
+							Computed:    true,
+						},
+						"addr_not_available_num": schema.Int64Attribute{
+							Description: "The number of unavailable addresses.",
+							Computed:    true,
+						},
+						"addr_pool_group_not_available_num": schema.Int64Attribute{
+							Description: "The number of unavailable address pool sets.",
+							Computed:    true,
+						},
+						"strategy_not_available_num": schema.Int64Attribute{
+							Description: "The number of unavailable access strategies.",
+							Computed:    true,
+						},
+						"switch_to_failover_strategy_num": schema.Int64Attribute{
+							Description: "The number of access strategies switched to the failover address pool set.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *alidnsGtmInstancesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.baseClient = req.ProviderData.(alicloudClients).baseClient
+	d.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+func (d *alidnsGtmInstancesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan *alidnsGtmInstancesDataSourceModel
+	getPlanDiags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !plan.NameRegex.IsNull() {
+		var err error
+		if nameRegex, err = regexp.Compile(plan.NameRegex.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"[Input Error] Failed to Convert Name Input to Regex",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	gtmInstances, err := d.listGtmInstances(plan.ResourceGroupId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe GTM Instances",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &alidnsGtmInstancesDataSourceModel{
+		NameRegex:       plan.NameRegex,
+		ResourceGroupId: plan.ResourceGroupId,
+		PackageEdition:  plan.PackageEdition,
+		Instances:       []*alidnsGtmInstancesElement{},
+	}
+	for _, gtmInstance := range gtmInstances {
+		config := gtmInstance.Config
+		if config == nil {
+			config = &alicloudDnsClient.DescribeDnsGtmInstancesResponseBodyGtmInstancesConfig{}
+		}
+		if nameRegex != nil && !nameRegex.MatchString(tea.StringValue(config.InstanceName)) {
+			continue
+		}
+		if !plan.PackageEdition.IsNull() && plan.PackageEdition.ValueString() != tea.StringValue(gtmInstance.VersionCode) {
+			continue
+		}
+
+		// Same as the GTM instance resource, only the instances of cn
+		// accounts have the SMS quota.
+		instanceType := "intl"
+		if gtmInstance.UsedQuota != nil && gtmInstance.UsedQuota.SmsUsedCount != nil {
+			instanceType = "cn"
+		}
+
+		instance := &alidnsGtmInstancesElement{
+			Id:                   types.StringValue(tea.StringValue(gtmInstance.InstanceId)),
+			InstanceName:         types.StringValue(tea.StringValue(config.InstanceName)),
+			InstanceType:         types.StringValue(instanceType),
+			PackageEdition:       types.StringValue(tea.StringValue(gtmInstance.VersionCode)),
+			PaymentType:          types.StringValue(tea.StringValue(gtmInstance.PaymentType)),
+			ResourceGroupId:      types.StringValue(tea.StringValue(gtmInstance.ResourceGroupId)),
+			StrategyMode:         types.StringValue(tea.StringValue(config.StrategyMode)),
+			Ttl:                  types.Int64Value(int64(tea.Int32Value(config.Ttl))),
+			Cname:                types.StringNull(),
+			PublicUserDomainName: types.StringValue(tea.StringValue(config.PublicUserDomainName)),
+			CreateTime:           types.StringValue(tea.StringValue(gtmInstance.CreateTime)),
+			ExpireTime:           types.StringValue(tea.StringValue(gtmInstance.ExpireTime)),
+			ExpireTimestamp:      types.Int64Value(tea.Int64Value(gtmInstance.ExpireTimestamp)),
+			HealthCheckTaskCount: types.Int64Value(int64(tea.Int32Value(gtmInstance.TaskQuota))),
+		}
+		if config.PublicRr != nil && config.PublicZoneName != nil {
+			instance.Cname = types.StringValue(tea.StringValue(config.PublicRr) + "." + tea.StringValue(config.PublicZoneName))
+		}
+		if gtmInstance.UsedQuota != nil {
+			instance.HealthCheckTaskUsedCount = types.Int64Value(int64(tea.Int32Value(gtmInstance.UsedQuota.TaskUsedCount)))
+		} else {
+			instance.HealthCheckTaskUsedCount = types.Int64Value(0)
+		}
+
+		if err := d.readCounts(instance); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Describe GTM Address Pools and Access Strategies",
+				err.Error(),
+			)
+			return
+		}
+		if err := d.readStatus(instance); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Describe GTM Instance Status",
+				err.Error(),
+			)
+			return
+		}
+
+		state.Instances = append(state.Instances, instance)
+	}
+
+	if err := d.readRenewals(state.Instances); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Query GTM Instance Renewal",
+			err.Error(),
+		)
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (d *alidnsGtmInstancesDataSource) listGtmInstances(resourceGroupId string) (gtmInstances []*alicloudDnsClient.DescribeDnsGtmInstancesResponseBodyGtmInstances, err error) {
+	describeDnsGtmInstances := func() error {
+		runtime := &util.RuntimeOptions{}
+		gtmInstances = nil
+
+		describeDnsGtmInstancesRequest := &alicloudDnsClient.DescribeDnsGtmInstancesRequest{
+			PageNumber: tea.Int32(1),
+			PageSize:   tea.Int32(100),
+		}
+		if resourceGroupId != "" {
+			describeDnsGtmInstancesRequest.ResourceGroupId = tea.String(resourceGroupId)
+		}
+
+		for {
+			describeDnsGtmInstancesResponse, err := d.client.DescribeDnsGtmInstancesWithOptions(describeDnsGtmInstancesRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+
+			if len(describeDnsGtmInstancesResponse.Body.GtmInstances) == 0 {
+				break
+			}
+			gtmInstances = append(gtmInstances, describeDnsGtmInstancesResponse.Body.GtmInstances...)
+			if len(gtmInstances) >= int(tea.Int32Value(describeDnsGtmInstancesResponse.Body.TotalItems)) {
+				break
+			}
+			describeDnsGtmInstancesRequest.PageNumber = tea.Int32(tea.Int32Value(describeDnsGtmInstancesRequest.PageNumber) + 1)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(describeDnsGtmInstances, reconnectBackoff)
+	return
+}
+
+// readRenewals reads the renewal status of the instances from BSS. The
+// instances are queried in batches on the endpoint of their type, with a
+// client of their own so that the shared client is left untouched.
+func (d *alidnsGtmInstancesDataSource) readRenewals(instances []*alidnsGtmInstancesElement) error {
+	instanceIds := map[string][]string{}
+	for _, instance := range instances {
+		instance.RenewalStatus = types.StringNull()
+		instance.RenewPeriod = types.Int64Value(0)
+		instanceType := instance.InstanceType.ValueString()
+		instanceIds[instanceType] = append(instanceIds[instanceType], instance.Id.ValueString())
+	}
+
+	availableInstances := map[string]*alicloudBaseClient.QueryAvailableInstancesResponseBodyDataInstanceList{}
+	for instanceType, ids := range instanceIds {
+		clientEndpoint, productType := getGtmBssProduct(instanceType)
+		bssClient, err := newBssClient(d.baseClient, clientEndpoint)
+		if err != nil {
+			return err
+		}
+
+		for start := 0; start < len(ids); start += alidnsGtmInstancesQueryBatchSize {
+			end := start + alidnsGtmInstancesQueryBatchSize
+			if end > len(ids) {
+				end = len(ids)
+			}
+
+			queryAvailableInstances := func() error {
+				runtime := &util.RuntimeOptions{}
+
+				queryAvailableInstancesRequest := &alicloudBaseClient.QueryAvailableInstancesRequest{
+					InstanceIDs: tea.String(strings.Join(ids[start:end], ",")),
+					ProductCode: tea.String("dns"),
+					ProductType: tea.String(productType),
+					PageSize:    tea.Int32(alidnsGtmInstancesQueryBatchSize),
+				}
+
+				queryAvailableInstancesResponse, err := bssClient.QueryAvailableInstancesWithOptions(queryAvailableInstancesRequest, runtime)
+				if err != nil {
+					return handleAPIError(err)
+				}
+				if data := queryAvailableInstancesResponse.Body.Data; data != nil {
+					for _, availableInstance := range data.InstanceList {
+						availableInstances[tea.StringValue(availableInstance.InstanceID)] = availableInstance
+					}
+				}
+				return nil
+			}
+
+			reconnectBackoff := backoff.NewExponentialBackOff()
+			reconnectBackoff.MaxElapsedTime = 30 * time.Second
+			if err := backoff.Retry(queryAvailableInstances, reconnectBackoff); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, instance := range instances {
+		availableInstance, ok := availableInstances[instance.Id.ValueString()]
+		if !ok {
+			continue
+		}
+		instance.RenewalStatus = types.StringValue(tea.StringValue(availableInstance.RenewStatus))
+		if tea.StringValue(availableInstance.RenewStatus) == "AutoRenewal" {
+			instance.RenewPeriod = types.Int64Value(getBssRenewPeriod(availableInstance))
+		}
+	}
+	return nil
+}
+
+// readCounts reads the number of the address pools and the access strategies
+// of the instance from the total items of the first pages.
+func (d *alidnsGtmInstancesDataSource) readCounts(instance *alidnsGtmInstancesElement) error {
+	describeCounts := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		describeDnsGtmInstanceAddressPoolsRequest := &alicloudDnsClient.DescribeDnsGtmInstanceAddressPoolsRequest{
+			InstanceId: tea.String(instance.Id.ValueString()),
+			PageNumber: tea.Int32(1),
+			PageSize:   tea.Int32(1),
+		}
+		describeDnsGtmInstanceAddressPoolsResponse, err := d.client.DescribeDnsGtmInstanceAddressPoolsWithOptions(describeDnsGtmInstanceAddressPoolsRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		instance.AddressPoolCount = types.Int64Value(int64(tea.Int32Value(describeDnsGtmInstanceAddressPoolsResponse.Body.TotalItems)))
+
+		describeDnsGtmAccessStrategiesRequest := &alicloudDnsClient.DescribeDnsGtmAccessStrategiesRequest{
+			InstanceId:   tea.String(instance.Id.ValueString()),
+			StrategyMode: tea.String(instance.StrategyMode.ValueString()),
+			PageNumber:   tea.Int32(1),
+			PageSize:     tea.Int32(1),
+		}
+		describeDnsGtmAccessStrategiesResponse, err := d.client.DescribeDnsGtmAccessStrategiesWithOptions(describeDnsGtmAccessStrategiesRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		instance.AccessStrategyCount = types.Int64Value(int64(tea.Int32Value(describeDnsGtmAccessStrategiesResponse.Body.TotalItems)))
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(describeCounts, reconnectBackoff)
+}
+
+func (d *alidnsGtmInstancesDataSource) readStatus(instance *alidnsGtmInstancesElement) error {
+	describeDnsGtmInstanceStatus := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		describeDnsGtmInstanceStatusRequest := &alicloudDnsClient.DescribeDnsGtmInstanceStatusRequest{
+			InstanceId: tea.String(instance.Id.ValueString()),
+		}
+
+		describeDnsGtmInstanceStatusResponse, err := d.client.DescribeDnsGtmInstanceStatusWithOptions(describeDnsGtmInstanceStatusRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+
+		status := describeDnsGtmInstanceStatusResponse.Body
+		instance.AddrAvailableNum = types.Int64Value(int64(tea.Int32Value(status.AddrAvailableNum)))
+		instance.AddrNotAvailableNum = types.Int64Value(int64(tea.Int32Value(status.AddrNotAvailableNum)))
+		instance.AddrPoolGroupNotAvailableNum = types.Int64Value(int64(tea.Int32Value(status.AddrPoolGroupNotAvailableNum)))
+		instance.StrategyNotAvailableNum = types.Int64Value(int64(tea.Int32Value(status.StrategyNotAvailableNum)))
+		instance.SwitchToFailoverStrategyNum = types.Int64Value(int64(tea.Int32Value(status.SwitchToFailoverStrategyNum)))
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(describeDnsGtmInstanceStatus, reconnectBackoff)
+}
@@ -0,0 +1,316 @@
+package alicloud
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	alicloudBaseClient "github.com/alibabacloud-go/bssopenapi-20171214/v3/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+// alidnsInstancesQueryBatchSize is the maximum number of the instance IDs
+// queried from BSS at a time.
+const alidnsInstancesQueryBatchSize = 100
+
+var (
+	_ datasource.DataSource              = &alidnsInstancesDataSource{}
+	_ datasource.DataSourceWithConfigure = &alidnsInstancesDataSource{}
+)
+
+func NewAlidnsInstancesDataSource() datasource.DataSource {
+	return &alidnsInstancesDataSource{}
+}
+
+type alidnsInstancesDataSource struct {
+	baseClient *alicloudBaseClient.Client
+	client     *alicloudDnsClient.Client
+}
+
+type alidnsInstancesDataSourceModel struct {
+	VersionCode      types.String              `tfsdk:"version_code"`
+	ExpireWithinDays types.Int64               `tfsdk:"expire_within_days"`
+	Instances        []*alidnsInstancesElement `tfsdk:"instances"`
+}
+
+type alidnsInstancesElement struct {
+	InstanceId          types.String `tfsdk:"instance_id"`
+	VersionCode         types.String `tfsdk:"version_code"`
+	VersionName         types.String `tfsdk:"version_name"`
+	PaymentType         types.String `tfsdk:"payment_type"`
+	DomainNumbers       types.Int64  `tfsdk:"domain_numbers"`
+	BindDomainUsedCount types.Int64  `tfsdk:"bind_domain_used_count"`
+	Domain              types.String `tfsdk:"domain"`
+	StartTime           types.String `tfsdk:"start_time"`
+	EndTime             types.String `tfsdk:"end_time"`
+	EndTimestamp        types.Int64  `tfsdk:"end_timestamp"`
+	RenewalStatus       types.String `tfsdk:"renewal_status"`
+	RenewPeriod         types.Int64  `tfsdk:"renew_period"`
+}
+
+func (d *alidnsInstancesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_instances"
+}
+
+func (d *alidnsInstancesDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source provides the paid DNS instances of the current AliCloud user, with their " +
+			"domain quota, expiry and renewal status.",
+		Attributes: map[string]schema.Attribute{
+			"version_code": schema.StringAttribute{
+				Description: "The version code to filter the instances, e.g. version_personal, version_enterprise_basic.",
+				Optional:    true,
+			},
+			"expire_within_days": schema.Int64Attribute{
+				Description: "Only return the instances that expire within the number of days, including the expired ones.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"instances": schema.ListNestedAttribute{
+				Description: "A list of DNS instances.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"instance_id": schema.StringAttribute{
+							Description: "The ID of the instance.",
+							Computed:    true,
+						},
+						"version_code": schema.StringAttribute{
+							Description: "The version code of the instance.",
+							Computed:    true,
+						},
+						"version_name": schema.StringAttribute{
+							Description: "The version name of the instance.",
+							Computed:    true,
+						},
+						"payment_type": schema.StringAttribute{
+							Description: "The payment type of the instance.",
+							Computed:    true,
+						},
+						"domain_numbers": schema.Int64Attribute{
+							Description: "The number of domains that can be bound to the instance.",
+							Computed:    true,
+						},
+						"bind_domain_used_count": schema.Int64Attribute{
+							Description: "The number of domains bound to the instance.",
+							Computed:    true,
+						},
+						"domain": schema.StringAttribute{
+							Description: "The domain bound to the instance, for the versions with a single domain.",
+							Computed:    true,
+						},
+						"start_time": schema.StringAttribute{
+							Description: "The time when the instance was purchased.",
+							Computed:    true,
+						},
+						"end_time": schema.StringAttribute{
+							Description: "The time when the instance expires.",
+							Computed:    true,
+						},
+						"end_timestamp": schema.Int64Attribute{
+							Description: "The time when the instance expires, in milliseconds since the epoch.",
+							Computed:    true,
+						},
+						"renewal_status": schema.StringAttribute{
+							Description: "The renewal status of the instance, AutoRenewal, ManualRenewal or NotRenewal.",
+							Computed:    true,
+						},
+						"renew_period": schema.Int64Attribute{
+							Description: "The automatic renewal period of the instance, the unit is month.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *alidnsInstancesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.baseClient = req.ProviderData.(alicloudClients).baseClient
+	d.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+func (d *alidnsInstancesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan *alidnsInstancesDataSourceModel
+	getPlanDiags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dnsProducts, err := d.listDnsProducts(plan.VersionCode.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe DNS Instances",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &alidnsInstancesDataSourceModel{
+		VersionCode:      plan.VersionCode,
+		ExpireWithinDays: plan.ExpireWithinDays,
+		Instances:        []*alidnsInstancesElement{},
+	}
+	instanceIds := []string{}
+	for _, dnsProduct := range dnsProducts {
+		if !plan.ExpireWithinDays.IsNull() {
+			expireBefore := time.Now().AddDate(0, 0, int(plan.ExpireWithinDays.ValueInt64())).UnixMilli()
+			if tea.Int64Value(dnsProduct.EndTimestamp) > expireBefore {
+				continue
+			}
+		}
+
+		instance := &alidnsInstancesElement{
+			InstanceId:          types.StringValue(tea.StringValue(dnsProduct.InstanceId)),
+			VersionCode:         types.StringValue(tea.StringValue(dnsProduct.VersionCode)),
+			VersionName:         types.StringValue(tea.StringValue(dnsProduct.VersionName)),
+			PaymentType:         types.StringValue(tea.StringValue(dnsProduct.PaymentType)),
+			DomainNumbers:       types.Int64Value(tea.Int64Value(dnsProduct.BindDomainCount)),
+			BindDomainUsedCount: types.Int64Value(tea.Int64Value(dnsProduct.BindDomainUsedCount)),
+			Domain:              types.StringNull(),
+			StartTime:           types.StringValue(tea.StringValue(dnsProduct.StartTime)),
+			EndTime:             types.StringValue(tea.StringValue(dnsProduct.EndTime)),
+			EndTimestamp:        types.Int64Value(tea.Int64Value(dnsProduct.EndTimestamp)),
+			RenewalStatus:       types.StringNull(),
+			RenewPeriod:         types.Int64Value(0),
+		}
+		if tea.StringValue(dnsProduct.Domain) != "" {
+			instance.Domain = types.StringValue(tea.StringValue(dnsProduct.Domain))
+		}
+		state.Instances = append(state.Instances, instance)
+		instanceIds = append(instanceIds, instance.InstanceId.ValueString())
+	}
+
+	availableInstances, err := d.queryAvailableInstances(instanceIds)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Query DNS Instance Renewal",
+			err.Error(),
+		)
+		return
+	}
+	for _, instance := range state.Instances {
+		availableInstance, ok := availableInstances[instance.InstanceId.ValueString()]
+		if !ok {
+			continue
+		}
+		instance.RenewalStatus = types.StringValue(tea.StringValue(availableInstance.RenewStatus))
+		if tea.StringValue(availableInstance.RenewStatus) == "AutoRenewal" {
+			instance.RenewPeriod = types.Int64Value(getBssRenewPeriod(availableInstance))
+		}
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (d *alidnsInstancesDataSource) listDnsProducts(versionCode string) (dnsProducts []*alicloudDnsClient.DescribeDnsProductInstancesResponseBodyDnsProductsDnsProduct, err error) {
+	describeDnsProductInstances := func() error {
+		runtime := &util.RuntimeOptions{}
+		dnsProducts = nil
+
+		describeDnsProductInstancesRequest := &alicloudDnsClient.DescribeDnsProductInstancesRequest{
+			PageNumber: tea.Int64(1),
+			PageSize:   tea.Int64(100),
+		}
+		if versionCode != "" {
+			describeDnsProductInstancesRequest.VersionCode = tea.String(versionCode)
+		}
+
+		for {
+			describeDnsProductInstancesResponse, err := d.client.DescribeDnsProductInstancesWithOptions(describeDnsProductInstancesRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+
+			products := describeDnsProductInstancesResponse.Body.DnsProducts
+			if products == nil || len(products.DnsProduct) == 0 {
+				break
+			}
+			dnsProducts = append(dnsProducts, products.DnsProduct...)
+			if int64(len(dnsProducts)) >= tea.Int64Value(describeDnsProductInstancesResponse.Body.TotalCount) {
+				break
+			}
+			describeDnsProductInstancesRequest.PageNumber = tea.Int64(tea.Int64Value(describeDnsProductInstancesRequest.PageNumber) + 1)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(describeDnsProductInstances, reconnectBackoff)
+	return
+}
+
+// queryAvailableInstances queries the renewal of the instances from BSS in
+// batches. Same as the DNS instance resource, the international endpoint is
+// used when the instances are not applicable to the China endpoint. The
+// clients are created for the query, the shared client is left untouched.
+func (d *alidnsInstancesDataSource) queryAvailableInstances(instanceIds []string) (map[string]*alicloudBaseClient.QueryAvailableInstancesResponseBodyDataInstanceList, error) {
+	bssClient, err := newBssClient(d.baseClient, bssChinaEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	availableInstances := map[string]*alicloudBaseClient.QueryAvailableInstancesResponseBodyDataInstanceList{}
+	for start := 0; start < len(instanceIds); start += alidnsInstancesQueryBatchSize {
+		end := start + alidnsInstancesQueryBatchSize
+		if end > len(instanceIds) {
+			end = len(instanceIds)
+		}
+
+		queryAvailableInstances := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			queryAvailableInstancesRequest := &alicloudBaseClient.QueryAvailableInstancesRequest{
+				InstanceIDs: tea.String(strings.Join(instanceIds[start:end], ",")),
+				ProductCode: tea.String("dns"),
+				PageSize:    tea.Int32(alidnsInstancesQueryBatchSize),
+			}
+
+			queryAvailableInstancesResponse, err := bssClient.QueryAvailableInstancesWithOptions(queryAvailableInstancesRequest, runtime)
+			if err != nil {
+				if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == ERR_NOT_APPLICABLE && tea.StringValue(bssClient.Endpoint) == bssChinaEndpoint {
+					if bssClient, err = newBssClient(d.baseClient, bssInternationalEndpoint); err != nil {
+						return backoff.Permanent(err)
+					}
+					return _t
+				}
+				return handleAPIError(err)
+			}
+			if data := queryAvailableInstancesResponse.Body.Data; data != nil {
+				for _, availableInstance := range data.InstanceList {
+					availableInstances[tea.StringValue(availableInstance.InstanceID)] = availableInstance
+				}
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(queryAvailableInstances, reconnectBackoff); err != nil {
+			return nil, err
+		}
+	}
+	return availableInstances, nil
+}
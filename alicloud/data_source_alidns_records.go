@@ -0,0 +1,397 @@
+package alicloud
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	openapi "github.com/alibabacloud-go/darabonba-openapi/v2/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ datasource.DataSource              = &alidnsRecordsDataSource{}
+	_ datasource.DataSourceWithConfigure = &alidnsRecordsDataSource{}
+)
+
+func NewAlidnsRecordsDataSource() datasource.DataSource {
+	return &alidnsRecordsDataSource{}
+}
+
+type alidnsRecordsDataSource struct {
+	client *alicloudDnsClient.Client
+}
+
+type alidnsRecordsDataSourceModel struct {
+	DomainName   types.String    `tfsdk:"domain_name"`
+	RRRegex      types.String    `tfsdk:"rr_regex"`
+	Type         types.String    `tfsdk:"type"`
+	Line         types.String    `tfsdk:"line"`
+	Status       types.String    `tfsdk:"status"`
+	ValueKeyword types.String    `tfsdk:"value_keyword"`
+	GroupId      types.String    `tfsdk:"group_id"`
+	Ids          types.List      `tfsdk:"ids"`
+	Records      []*alidnsRecord `tfsdk:"records"`
+}
+
+type alidnsRecord struct {
+	RecordId        types.String `tfsdk:"record_id"`
+	DomainName      types.String `tfsdk:"domain_name"`
+	RR              types.String `tfsdk:"rr"`
+	Type            types.String `tfsdk:"type"`
+	Value           types.String `tfsdk:"value"`
+	Line            types.String `tfsdk:"line"`
+	TTL             types.Int64  `tfsdk:"ttl"`
+	Priority        types.Int64  `tfsdk:"priority"`
+	Weight          types.Int64  `tfsdk:"weight"`
+	Status          types.String `tfsdk:"status"`
+	Locked          types.Bool   `tfsdk:"locked"`
+	Remark          types.String `tfsdk:"remark"`
+	CreateTimestamp types.Int64  `tfsdk:"create_timestamp"`
+	UpdateTimestamp types.Int64  `tfsdk:"update_timestamp"`
+}
+
+// The SDK does not parse the timestamps of the records yet, so the records
+// are listed through CallApi with these types.
+type alidnsDescribeDomainRecordsResponse struct {
+	Body struct {
+		TotalCount    *int64 `json:"TotalCount"`
+		DomainRecords *struct {
+			Record []*alidnsDescribeDomainRecordsRecord `json:"Record"`
+		} `json:"DomainRecords"`
+	} `json:"body"`
+}
+
+type alidnsDescribeDomainRecordsRecord struct {
+	RecordId        *string `json:"RecordId"`
+	DomainName      *string `json:"DomainName"`
+	RR              *string `json:"RR"`
+	Type            *string `json:"Type"`
+	Value           *string `json:"Value"`
+	Line            *string `json:"Line"`
+	TTL             *int64  `json:"TTL"`
+	Priority        *int64  `json:"Priority"`
+	Weight          *int32  `json:"Weight"`
+	Status          *string `json:"Status"`
+	Locked          *bool   `json:"Locked"`
+	Remark          *string `json:"Remark"`
+	CreateTimestamp *int64  `json:"CreateTimestamp"`
+	UpdateTimestamp *int64  `json:"UpdateTimestamp"`
+}
+
+func (d *alidnsRecordsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_records"
+}
+
+func (d *alidnsRecordsDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source provides the DNS records of a domain, paging through all the records.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description: "The domain name of the records.",
+				Required:    true,
+			},
+			"rr_regex": schema.StringAttribute{
+				Description: "A regex string to filter the records by host record.",
+				Optional:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Filter the records by type, e.g. A.",
+				Optional:    true,
+			},
+			"line": schema.StringAttribute{
+				Description: "Filter the records by resolution line, e.g. default.",
+				Optional:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Filter the records by status. Valid values: ENABLE, DISABLE.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ENABLE", "DISABLE"),
+				},
+			},
+			"value_keyword": schema.StringAttribute{
+				Description: "Filter the records whose value contains the keyword.",
+				Optional:    true,
+			},
+			"group_id": schema.StringAttribute{
+				Description: "Filter the records by the ID of the domain group.",
+				Optional:    true,
+			},
+			"ids": schema.ListAttribute{
+				Description: "A list of the IDs of the records.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"records": schema.ListNestedAttribute{
+				Description: "A list of DNS records.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"record_id": schema.StringAttribute{
+							Description: "ID of the record.",
+							Computed:    true,
+						},
+						"domain_name": schema.StringAttribute{
+							Description: "Domain name of the record.",
+							Computed:    true,
+						},
+						"rr": schema.StringAttribute{
+							Description: "Host record of the record.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Type of the record.",
+							Computed:    true,
+						},
+						"value": schema.StringAttribute{
+							Description: "Value of the record.",
+							Computed:    true,
+						},
+						"line": schema.StringAttribute{
+							Description: "Resolution line of the record.",
+							Computed:    true,
+						},
+						"ttl": schema.Int64Attribute{
+							Description: "TTL of the record in seconds.",
+							Computed:    true,
+						},
+						"priority": schema.Int64Attribute{
+							Description: "Priority of the MX record.",
+							Computed:    true,
+						},
+						"weight": schema.Int64Attribute{
+							Description: "Weight of the record in weighted round robin.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Status of the record.",
+							Computed:    true,
+						},
+						"locked": schema.BoolAttribute{
+							Description: "Whether the record is locked.",
+							Computed:    true,
+						},
+						"remark": schema.StringAttribute{
+							Description: "Remark of the record.",
+							Computed:    true,
+						},
+						"create_timestamp": schema.Int64Attribute{
+							Description: "Create time of the record in milliseconds since the epoch.",
+							Computed:    true,
+						},
+						"update_timestamp": schema.Int64Attribute{
+							Description: "Update time of the record in milliseconds since the epoch.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *alidnsRecordsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+func (d *alidnsRecordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan *alidnsRecordsDataSourceModel
+	getPlanDiags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var rrRegex *regexp.Regexp
+	if !(plan.RRRegex.IsNull() || plan.RRRegex.IsUnknown()) {
+		r, err := regexp.Compile(plan.RRRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rr_regex"),
+				"[Input Error] Invalid RR Regex",
+				err.Error(),
+			)
+			return
+		}
+		rrRegex = r
+	}
+
+	isSet := func(value types.String) bool {
+		return !(value.IsNull() || value.IsUnknown())
+	}
+
+	// The filters are applied by the API with the advanced search mode, and
+	// the exact filters are checked again on the returned records.
+	query := map[string]*string{
+		"SearchMode": tea.String("ADVANCED"),
+	}
+	if isSet(plan.Type) {
+		query["Type"] = tea.String(plan.Type.ValueString())
+	}
+	if isSet(plan.Line) {
+		query["Line"] = tea.String(plan.Line.ValueString())
+	}
+	if isSet(plan.Status) {
+		query["Status"] = tea.String(plan.Status.ValueString())
+	}
+	if isSet(plan.ValueKeyword) {
+		query["ValueKeyWord"] = tea.String(plan.ValueKeyword.ValueString())
+	}
+	if isSet(plan.GroupId) {
+		query["GroupId"] = tea.String(plan.GroupId.ValueString())
+	}
+
+	records, err := listAlidnsDomainRecords(d.client, plan.DomainName.ValueString(), query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Domain Records",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &alidnsRecordsDataSourceModel{
+		DomainName:   plan.DomainName,
+		RRRegex:      plan.RRRegex,
+		Type:         plan.Type,
+		Line:         plan.Line,
+		Status:       plan.Status,
+		ValueKeyword: plan.ValueKeyword,
+		GroupId:      plan.GroupId,
+		Records:      []*alidnsRecord{},
+	}
+
+	for _, record := range records {
+		if rrRegex != nil && !rrRegex.MatchString(tea.StringValue(record.RR)) {
+			continue
+		}
+		if isSet(plan.Type) && tea.StringValue(record.Type) != plan.Type.ValueString() {
+			continue
+		}
+		if isSet(plan.Line) && tea.StringValue(record.Line) != plan.Line.ValueString() {
+			continue
+		}
+		if isSet(plan.Status) && tea.StringValue(record.Status) != plan.Status.ValueString() {
+			continue
+		}
+		if isSet(plan.ValueKeyword) && !strings.Contains(tea.StringValue(record.Value), plan.ValueKeyword.ValueString()) {
+			continue
+		}
+
+		state.Records = append(state.Records, &alidnsRecord{
+			RecordId:        types.StringValue(tea.StringValue(record.RecordId)),
+			DomainName:      types.StringValue(tea.StringValue(record.DomainName)),
+			RR:              types.StringValue(tea.StringValue(record.RR)),
+			Type:            types.StringValue(tea.StringValue(record.Type)),
+			Value:           types.StringValue(tea.StringValue(record.Value)),
+			Line:            types.StringValue(tea.StringValue(record.Line)),
+			TTL:             types.Int64Value(tea.Int64Value(record.TTL)),
+			Priority:        types.Int64PointerValue(record.Priority),
+			Weight:          types.Int64Value(int64(tea.Int32Value(record.Weight))),
+			Status:          types.StringValue(tea.StringValue(record.Status)),
+			Locked:          types.BoolValue(tea.BoolValue(record.Locked)),
+			Remark:          types.StringValue(tea.StringValue(record.Remark)),
+			CreateTimestamp: types.Int64PointerValue(record.CreateTimestamp),
+			UpdateTimestamp: types.Int64PointerValue(record.UpdateTimestamp),
+		})
+	}
+
+	ids := []string{}
+	for _, record := range state.Records {
+		ids = append(ids, record.RecordId.ValueString())
+	}
+	idsList, diags := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Ids = idsList
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// listAlidnsDomainRecords returns all the records of the domain that match
+// the DescribeDomainRecords query, paging through the results.
+func listAlidnsDomainRecords(client *alicloudDnsClient.Client, domainName string, filters map[string]*string) (records []*alidnsDescribeDomainRecordsRecord, err error) {
+	query := map[string]*string{
+		"DomainName": tea.String(domainName),
+		"PageSize":   tea.String("500"),
+	}
+	for key, value := range filters {
+		query[key] = value
+	}
+
+	describeDomainRecords := func() error {
+		runtime := &util.RuntimeOptions{}
+		records = []*alidnsDescribeDomainRecordsRecord{}
+
+		for pageNumber := int64(1); ; pageNumber++ {
+			query["PageNumber"] = tea.String(strconv.FormatInt(pageNumber, 10))
+			describeDomainRecordsRequest := &openapi.OpenApiRequest{
+				Query: query,
+			}
+
+			body, err := client.CallApi(alidnsApiParams("DescribeDomainRecords"), describeDomainRecordsRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			describeDomainRecordsResponse := &alidnsDescribeDomainRecordsResponse{}
+			if err := tea.Convert(body, describeDomainRecordsResponse); err != nil {
+				return backoff.Permanent(err)
+			}
+
+			domainRecords := describeDomainRecordsResponse.Body.DomainRecords
+			if domainRecords == nil || len(domainRecords.Record) == 0 {
+				break
+			}
+			records = append(records, domainRecords.Record...)
+
+			if int64(len(records)) >= tea.Int64Value(describeDomainRecordsResponse.Body.TotalCount) {
+				break
+			}
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(describeDomainRecords, reconnectBackoff)
+	return
+}
+
+// alidnsApiParams returns the parameters to call the Alidns API action
+// through CallApi, for the fields that are not supported by the SDK yet.
+func alidnsApiParams(action string) *openapi.Params {
+	return &openapi.Params{
+		Action:      tea.String(action),
+		Version:     tea.String("2015-01-09"),
+		Protocol:    tea.String("HTTPS"),
+		Pathname:    tea.String("/"),
+		Method:      tea.String("POST"),
+		AuthType:    tea.String("AK"),
+		Style:       tea.String("RPC"),
+		ReqBodyType: tea.String("formData"),
+		BodyType:    tea.String("json"),
+	}
+}
@@ -0,0 +1,92 @@
+package alicloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+)
+
+var (
+	_ datasource.DataSource              = &alidnsZoneFileDataSource{}
+	_ datasource.DataSourceWithConfigure = &alidnsZoneFileDataSource{}
+)
+
+func NewAlidnsZoneFileDataSource() datasource.DataSource {
+	return &alidnsZoneFileDataSource{}
+}
+
+type alidnsZoneFileDataSource struct {
+	client *alicloudDnsClient.Client
+}
+
+type alidnsZoneFileDataSourceModel struct {
+	DomainName  types.String `tfsdk:"domain_name"`
+	Content     types.String `tfsdk:"content"`
+	RecordCount types.Int64  `tfsdk:"record_count"`
+}
+
+func (d *alidnsZoneFileDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_zone_file"
+}
+
+func (d *alidnsZoneFileDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source renders the DNS records of a domain on the default line as an RFC 1035 zone file.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description: "The domain name of the zone.",
+				Required:    true,
+			},
+			"content": schema.StringAttribute{
+				Description: "The zone file of the domain.",
+				Computed:    true,
+			},
+			"record_count": schema.Int64Attribute{
+				Description: "The number of records in the zone file.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *alidnsZoneFileDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+func (d *alidnsZoneFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan *alidnsZoneFileDataSourceModel
+	getPlanDiags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := listAlidnsZoneRecords(d.client, plan.DomainName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Domain Records",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &alidnsZoneFileDataSourceModel{
+		DomainName:  plan.DomainName,
+		Content:     types.StringValue(renderAlidnsZoneFile(plan.DomainName.ValueString(), records)),
+		RecordCount: types.Int64Value(int64(len(records))),
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,291 @@
+package alicloud
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudRamClient "github.com/alibabacloud-go/ram-20150501/v2/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ datasource.DataSource              = &ramGroupsDataSource{}
+	_ datasource.DataSourceWithConfigure = &ramGroupsDataSource{}
+)
+
+func NewRamGroupsDataSource() datasource.DataSource {
+	return &ramGroupsDataSource{}
+}
+
+type ramGroupsDataSource struct {
+	client *alicloudRamClient.Client
+}
+
+type ramGroupsDataSourceModel struct {
+	NameRegex  types.String `tfsdk:"name_regex"`
+	UserName   types.String `tfsdk:"user_name"`
+	PolicyName types.String `tfsdk:"policy_name"`
+	PolicyType types.String `tfsdk:"policy_type"`
+	Names      types.List   `tfsdk:"names"`
+	Groups     []*ramGroup  `tfsdk:"groups"`
+}
+
+type ramGroup struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Comments   types.String `tfsdk:"comments"`
+	CreateDate types.String `tfsdk:"create_date"`
+	UpdateDate types.String `tfsdk:"update_date"`
+}
+
+func (d *ramGroupsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ram_groups"
+}
+
+func (d *ramGroupsDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source provides the RAM groups of the current AliCloud user.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Description: "A regex string to filter the groups by name.",
+				Optional:    true,
+			},
+			"user_name": schema.StringAttribute{
+				Description: "Filter the groups that the user belongs to.",
+				Optional:    true,
+			},
+			"policy_name": schema.StringAttribute{
+				Description: "Filter the groups that the policy is attached to.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("policy_type")),
+				},
+			},
+			"policy_type": schema.StringAttribute{
+				Description: "The type of the policy filter. Valid values: System, Custom.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("System", "Custom"),
+					stringvalidator.AlsoRequires(path.MatchRoot("policy_name")),
+				},
+			},
+			"names": schema.ListAttribute{
+				Description: "A list of the names of the groups.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"groups": schema.ListNestedAttribute{
+				Description: "A list of RAM groups.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "ID of the group.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the group.",
+							Computed:    true,
+						},
+						"comments": schema.StringAttribute{
+							Description: "Comments of the group.",
+							Computed:    true,
+						},
+						"create_date": schema.StringAttribute{
+							Description: "Create date of the group.",
+							Computed:    true,
+						},
+						"update_date": schema.StringAttribute{
+							Description: "Update date of the group.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ramGroupsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(alicloudClients).ramClient
+}
+
+func (d *ramGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan *ramGroupsDataSourceModel
+	getPlanDiags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !(plan.NameRegex.IsNull() || plan.NameRegex.IsUnknown()) {
+		r, err := regexp.Compile(plan.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"[Input Error] Invalid Name Regex",
+				err.Error(),
+			)
+			return
+		}
+		nameRegex = r
+	}
+
+	// The names of the groups that match the principal filters, nil means no
+	// principal filter is configured.
+	var filterGroupNames map[string]bool
+	filterByGroupNames := func(groupNames []string) {
+		filtered := map[string]bool{}
+		for _, groupName := range groupNames {
+			if filterGroupNames == nil || filterGroupNames[groupName] {
+				filtered[groupName] = true
+			}
+		}
+		filterGroupNames = filtered
+	}
+
+	if !(plan.UserName.IsNull() || plan.UserName.IsUnknown()) {
+		groupNames, err := listRamUserGroups(d.client, plan.UserName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Read Groups for User",
+				err.Error(),
+			)
+			return
+		}
+		filterByGroupNames(groupNames)
+	}
+
+	if !(plan.PolicyName.IsNull() || plan.PolicyName.IsUnknown()) {
+		_, groupNames, err := listRamPolicyEntities(d.client, plan.PolicyName.ValueString(), plan.PolicyType.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Read Entities for Policy",
+				err.Error(),
+			)
+			return
+		}
+		filterByGroupNames(groupNames)
+	}
+
+	state := &ramGroupsDataSourceModel{
+		NameRegex:  plan.NameRegex,
+		UserName:   plan.UserName,
+		PolicyName: plan.PolicyName,
+		PolicyType: plan.PolicyType,
+		Groups:     []*ramGroup{},
+	}
+
+	listGroups := func() error {
+		runtime := &util.RuntimeOptions{}
+		state.Groups = []*ramGroup{}
+
+		listGroupsRequest := &alicloudRamClient.ListGroupsRequest{
+			MaxItems: tea.Int32(1000),
+		}
+
+		for {
+			listGroupsResponse, err := d.client.ListGroupsWithOptions(listGroupsRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+
+			if listGroupsResponse.Body.Groups != nil {
+				for _, group := range listGroupsResponse.Body.Groups.Group {
+					groupName := tea.StringValue(group.GroupName)
+					if nameRegex != nil && !nameRegex.MatchString(groupName) {
+						continue
+					}
+					if filterGroupNames != nil && !filterGroupNames[groupName] {
+						continue
+					}
+					state.Groups = append(state.Groups, &ramGroup{
+						ID:         types.StringValue(tea.StringValue(group.GroupId)),
+						Name:       types.StringValue(groupName),
+						Comments:   types.StringValue(tea.StringValue(group.Comments)),
+						CreateDate: types.StringValue(tea.StringValue(group.CreateDate)),
+						UpdateDate: types.StringValue(tea.StringValue(group.UpdateDate)),
+					})
+				}
+			}
+
+			if !tea.BoolValue(listGroupsResponse.Body.IsTruncated) {
+				break
+			}
+			listGroupsRequest.Marker = listGroupsResponse.Body.Marker
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(listGroups, reconnectBackoff)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to List Groups",
+			err.Error(),
+		)
+		return
+	}
+
+	names := []string{}
+	for _, group := range state.Groups {
+		names = append(names, group.Name.ValueString())
+	}
+	namesList, diags := types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Names = namesList
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// listRamUserGroups returns the names of the groups that the user belongs to.
+func listRamUserGroups(client *alicloudRamClient.Client, userName string) (groupNames []string, err error) {
+	listGroupsForUser := func() error {
+		runtime := &util.RuntimeOptions{}
+		groupNames = []string{}
+
+		listGroupsForUserRequest := &alicloudRamClient.ListGroupsForUserRequest{
+			UserName: tea.String(userName),
+		}
+
+		listGroupsForUserResponse, err := client.ListGroupsForUserWithOptions(listGroupsForUserRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+
+		if listGroupsForUserResponse.Body.Groups != nil {
+			for _, group := range listGroupsForUserResponse.Body.Groups.Group {
+				groupNames = append(groupNames, tea.StringValue(group.GroupName))
+			}
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(listGroupsForUser, reconnectBackoff)
+	return
+}
@@ -0,0 +1,341 @@
+package alicloud
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudRamClient "github.com/alibabacloud-go/ram-20150501/v2/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ datasource.DataSource              = &ramPoliciesDataSource{}
+	_ datasource.DataSourceWithConfigure = &ramPoliciesDataSource{}
+)
+
+func NewRamPoliciesDataSource() datasource.DataSource {
+	return &ramPoliciesDataSource{}
+}
+
+type ramPoliciesDataSource struct {
+	client *alicloudRamClient.Client
+}
+
+type ramPoliciesDataSourceModel struct {
+	NameRegex  types.String `tfsdk:"name_regex"`
+	PolicyType types.String `tfsdk:"policy_type"`
+	UserName   types.String `tfsdk:"user_name"`
+	GroupName  types.String `tfsdk:"group_name"`
+	Names      types.List   `tfsdk:"names"`
+	Policies   []*ramPolicy `tfsdk:"policies"`
+}
+
+type ramPolicy struct {
+	Name            types.String `tfsdk:"name"`
+	Type            types.String `tfsdk:"type"`
+	Description     types.String `tfsdk:"description"`
+	DefaultVersion  types.String `tfsdk:"default_version"`
+	AttachmentCount types.Int64  `tfsdk:"attachment_count"`
+	CreateDate      types.String `tfsdk:"create_date"`
+	UpdateDate      types.String `tfsdk:"update_date"`
+}
+
+func (d *ramPoliciesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ram_policies"
+}
+
+func (d *ramPoliciesDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source provides the RAM policies of the current AliCloud user.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Description: "A regex string to filter the policies by name.",
+				Optional:    true,
+			},
+			"policy_type": schema.StringAttribute{
+				Description: "Filter the policies by type. Valid values: System, Custom.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("System", "Custom"),
+				},
+			},
+			"user_name": schema.StringAttribute{
+				Description: "Filter the policies that are attached to the user directly.",
+				Optional:    true,
+			},
+			"group_name": schema.StringAttribute{
+				Description: "Filter the policies that are attached to the group.",
+				Optional:    true,
+			},
+			"names": schema.ListAttribute{
+				Description: "A list of the names of the policies.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"policies": schema.ListNestedAttribute{
+				Description: "A list of RAM policies.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Name of the policy.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Type of the policy.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Description of the policy.",
+							Computed:    true,
+						},
+						"default_version": schema.StringAttribute{
+							Description: "Default version of the policy.",
+							Computed:    true,
+						},
+						"attachment_count": schema.Int64Attribute{
+							Description: "Number of the principals that the policy is attached to.",
+							Computed:    true,
+						},
+						"create_date": schema.StringAttribute{
+							Description: "Create date of the policy.",
+							Computed:    true,
+						},
+						"update_date": schema.StringAttribute{
+							Description: "Update date of the policy.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ramPoliciesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(alicloudClients).ramClient
+}
+
+func (d *ramPoliciesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan *ramPoliciesDataSourceModel
+	getPlanDiags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !(plan.NameRegex.IsNull() || plan.NameRegex.IsUnknown()) {
+		r, err := regexp.Compile(plan.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"[Input Error] Invalid Name Regex",
+				err.Error(),
+			)
+			return
+		}
+		nameRegex = r
+	}
+
+	// The type and name of the policies that match the principal filters, nil
+	// means no principal filter is configured.
+	var filterPolicies map[string]bool
+	filterByPolicies := func(policies []*ramAttachedPolicy) {
+		filtered := map[string]bool{}
+		for _, policy := range policies {
+			key := policy.PolicyType + ":" + policy.PolicyName
+			if filterPolicies == nil || filterPolicies[key] {
+				filtered[key] = true
+			}
+		}
+		filterPolicies = filtered
+	}
+
+	if !(plan.UserName.IsNull() || plan.UserName.IsUnknown()) {
+		policies, err := listRamUserPolicies(d.client, plan.UserName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Read Policies for User",
+				err.Error(),
+			)
+			return
+		}
+		filterByPolicies(policies)
+	}
+
+	if !(plan.GroupName.IsNull() || plan.GroupName.IsUnknown()) {
+		policies, err := listRamGroupPolicies(d.client, plan.GroupName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Read Policies for Group",
+				err.Error(),
+			)
+			return
+		}
+		filterByPolicies(policies)
+	}
+
+	state := &ramPoliciesDataSourceModel{
+		NameRegex:  plan.NameRegex,
+		PolicyType: plan.PolicyType,
+		UserName:   plan.UserName,
+		GroupName:  plan.GroupName,
+		Policies:   []*ramPolicy{},
+	}
+
+	listPolicies := func() error {
+		runtime := &util.RuntimeOptions{}
+		state.Policies = []*ramPolicy{}
+
+		listPoliciesRequest := &alicloudRamClient.ListPoliciesRequest{
+			MaxItems: tea.Int32(1000),
+		}
+		if !(plan.PolicyType.IsNull() || plan.PolicyType.IsUnknown()) {
+			listPoliciesRequest.PolicyType = tea.String(plan.PolicyType.ValueString())
+		}
+
+		for {
+			listPoliciesResponse, err := d.client.ListPoliciesWithOptions(listPoliciesRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+
+			if listPoliciesResponse.Body.Policies != nil {
+				for _, policy := range listPoliciesResponse.Body.Policies.Policy {
+					policyName := tea.StringValue(policy.PolicyName)
+					policyType := tea.StringValue(policy.PolicyType)
+					if nameRegex != nil && !nameRegex.MatchString(policyName) {
+						continue
+					}
+					if filterPolicies != nil && !filterPolicies[policyType+":"+policyName] {
+						continue
+					}
+					state.Policies = append(state.Policies, &ramPolicy{
+						Name:            types.StringValue(policyName),
+						Type:            types.StringValue(policyType),
+						Description:     types.StringValue(tea.StringValue(policy.Description)),
+						DefaultVersion:  types.StringValue(tea.StringValue(policy.DefaultVersion)),
+						AttachmentCount: types.Int64Value(int64(tea.Int32Value(policy.AttachmentCount))),
+						CreateDate:      types.StringValue(tea.StringValue(policy.CreateDate)),
+						UpdateDate:      types.StringValue(tea.StringValue(policy.UpdateDate)),
+					})
+				}
+			}
+
+			if !tea.BoolValue(listPoliciesResponse.Body.IsTruncated) {
+				break
+			}
+			listPoliciesRequest.Marker = listPoliciesResponse.Body.Marker
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(listPolicies, reconnectBackoff)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to List Policies",
+			err.Error(),
+		)
+		return
+	}
+
+	names := []string{}
+	for _, policy := range state.Policies {
+		names = append(names, policy.Name.ValueString())
+	}
+	namesList, diags := types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Names = namesList
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// listRamUserPolicies returns the policies that are attached to the user directly.
+func listRamUserPolicies(client *alicloudRamClient.Client, userName string) (policies []*ramAttachedPolicy, err error) {
+	listPoliciesForUser := func() error {
+		runtime := &util.RuntimeOptions{}
+		policies = []*ramAttachedPolicy{}
+
+		listPoliciesForUserRequest := &alicloudRamClient.ListPoliciesForUserRequest{
+			UserName: tea.String(userName),
+		}
+
+		listPoliciesForUserResponse, err := client.ListPoliciesForUserWithOptions(listPoliciesForUserRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+
+		if listPoliciesForUserResponse.Body.Policies != nil {
+			for _, policy := range listPoliciesForUserResponse.Body.Policies.Policy {
+				policies = append(policies, &ramAttachedPolicy{
+					PolicyName: tea.StringValue(policy.PolicyName),
+					PolicyType: tea.StringValue(policy.PolicyType),
+					Source:     "user",
+				})
+			}
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(listPoliciesForUser, reconnectBackoff)
+	return
+}
+
+// listRamGroupPolicies returns the policies that are attached to the group.
+func listRamGroupPolicies(client *alicloudRamClient.Client, groupName string) (policies []*ramAttachedPolicy, err error) {
+	listPoliciesForGroup := func() error {
+		runtime := &util.RuntimeOptions{}
+		policies = []*ramAttachedPolicy{}
+
+		listPoliciesForGroupRequest := &alicloudRamClient.ListPoliciesForGroupRequest{
+			GroupName: tea.String(groupName),
+		}
+
+		listPoliciesForGroupResponse, err := client.ListPoliciesForGroupWithOptions(listPoliciesForGroupRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+
+		if listPoliciesForGroupResponse.Body.Policies != nil {
+			for _, policy := range listPoliciesForGroupResponse.Body.Policies.Policy {
+				policies = append(policies, &ramAttachedPolicy{
+					PolicyName: tea.StringValue(policy.PolicyName),
+					PolicyType: tea.StringValue(policy.PolicyType),
+					Source:     "group:" + groupName,
+				})
+			}
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(listPoliciesForGroup, reconnectBackoff)
+	return
+}
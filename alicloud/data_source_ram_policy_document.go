@@ -0,0 +1,550 @@
+package alicloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	ramPolicyActionRegex       = regexp.MustCompile(`^[a-z0-9-]+:[A-Za-z0-9*?]+$`)
+	ramPolicyResourceRegex     = regexp.MustCompile(`^acs:[a-z0-9*?-]+:[^:]*:[^:]*:.+$`)
+	ramPolicyConditionKeyRegex = regexp.MustCompile(`^[A-Za-z0-9-]+:[A-Za-z0-9_./-]+$`)
+)
+
+func getRamPolicyConditionOperators() []string {
+	return []string{
+		"StringEquals",
+		"StringNotEquals",
+		"StringEqualsIgnoreCase",
+		"StringNotEqualsIgnoreCase",
+		"StringLike",
+		"StringNotLike",
+		"NumericEquals",
+		"NumericNotEquals",
+		"NumericLessThan",
+		"NumericLessThanEquals",
+		"NumericGreaterThan",
+		"NumericGreaterThanEquals",
+		"DateEquals",
+		"DateNotEquals",
+		"DateLessThan",
+		"DateLessThanEquals",
+		"DateGreaterThan",
+		"DateGreaterThanEquals",
+		"Bool",
+		"IpAddress",
+		"NotIpAddress",
+	}
+}
+
+// RAM policy document in the format accepted by the RAM API.
+type ramPolicyDocument struct {
+	Version   string                `json:"Version"`
+	Statement []*ramPolicyStatement `json:"Statement"`
+}
+
+// ramPolicyStatement is a statement of the RAM policy document. The statements
+// with other elements are rejected when they are parsed, so that no element
+// is dropped silently when the documents are merged.
+type ramPolicyStatement struct {
+	// Sid identifies the statement when the documents are merged.
+	Sid         string                                    `json:"Sid,omitempty"`
+	Effect      string                                    `json:"Effect"`
+	Principal   json.RawMessage                           `json:"Principal,omitempty"`
+	Action      ramPolicyStringList                       `json:"Action,omitempty"`
+	NotAction   ramPolicyStringList                       `json:"NotAction,omitempty"`
+	Resource    ramPolicyStringList                       `json:"Resource,omitempty"`
+	NotResource ramPolicyStringList                       `json:"NotResource,omitempty"`
+	Condition   map[string]map[string]ramPolicyStringList `json:"Condition,omitempty"`
+}
+
+// ramPolicyStringList accepts both a single value and a list of values, as
+// the RAM policy language does for actions, resources and condition values.
+type ramPolicyStringList []string
+
+func (l *ramPolicyStringList) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var values []interface{}
+	if list, ok := raw.([]interface{}); ok {
+		values = list
+	} else {
+		values = []interface{}{raw}
+	}
+
+	result := ramPolicyStringList{}
+	for _, v := range values {
+		switch value := v.(type) {
+		case string:
+			result = append(result, value)
+		case bool:
+			result = append(result, strconv.FormatBool(value))
+		case float64:
+			result = append(result, strconv.FormatFloat(value, 'f', -1, 64))
+		default:
+			return fmt.Errorf("unsupported value %v", v)
+		}
+	}
+	*l = result
+	return nil
+}
+
+// parseRamPolicyDocument parses a RAM policy document in JSON format.
+func parseRamPolicyDocument(document string) (*ramPolicyDocument, error) {
+	raw := struct {
+		Version   string          `json:"Version"`
+		Statement json.RawMessage `json:"Statement"`
+	}{}
+	if err := json.Unmarshal([]byte(document), &raw); err != nil {
+		return nil, err
+	}
+
+	policyDocument := &ramPolicyDocument{
+		Version:   raw.Version,
+		Statement: []*ramPolicyStatement{},
+	}
+	if len(raw.Statement) == 0 {
+		return policyDocument, nil
+	}
+
+	// Statement may be a single statement object instead of a list.
+	decoder := json.NewDecoder(bytes.NewReader(raw.Statement))
+	decoder.DisallowUnknownFields()
+	if bytes.HasPrefix(bytes.TrimSpace(raw.Statement), []byte("{")) {
+		statement := &ramPolicyStatement{}
+		if err := decoder.Decode(statement); err != nil {
+			return nil, fmt.Errorf("unsupported statement: %w", err)
+		}
+		policyDocument.Statement = append(policyDocument.Statement, statement)
+	} else if err := decoder.Decode(&policyDocument.Statement); err != nil {
+		return nil, fmt.Errorf("unsupported statement: %w", err)
+	}
+
+	return policyDocument, nil
+}
+
+// validate returns the syntax errors of the policy document.
+func (doc *ramPolicyDocument) validate() (errs []error) {
+	if doc.Version != "1" {
+		errs = append(errs, fmt.Errorf("unsupported policy version %q, the only supported version is \"1\"", doc.Version))
+	}
+
+	for i, statement := range doc.Statement {
+		for _, err := range statement.validate() {
+			errs = append(errs, fmt.Errorf("statement %d: %w", i+1, err))
+		}
+	}
+	return errs
+}
+
+func (s *ramPolicyStatement) validate() (errs []error) {
+	if s.Effect != "Allow" && s.Effect != "Deny" {
+		errs = append(errs, fmt.Errorf("invalid effect %q, valid values: Allow, Deny", s.Effect))
+	}
+
+	switch {
+	case len(s.Action) == 0 && len(s.NotAction) == 0:
+		errs = append(errs, fmt.Errorf("at least one action or not action is required"))
+	case len(s.Action) > 0 && len(s.NotAction) > 0:
+		errs = append(errs, fmt.Errorf("action and not action can not be used together"))
+	}
+	for _, action := range append(append([]string{}, s.Action...), s.NotAction...) {
+		if action != "*" && !ramPolicyActionRegex.MatchString(action) {
+			errs = append(errs, fmt.Errorf("invalid action %q, the action must be * or in the format of <service>:<action>", action))
+		}
+	}
+
+	// The trust policies have a principal instead of resources.
+	switch {
+	case len(s.Resource) == 0 && len(s.NotResource) == 0 && len(s.Principal) == 0:
+		errs = append(errs, fmt.Errorf("at least one resource or not resource is required"))
+	case len(s.Resource) > 0 && len(s.NotResource) > 0:
+		errs = append(errs, fmt.Errorf("resource and not resource can not be used together"))
+	}
+	for _, resource := range append(append([]string{}, s.Resource...), s.NotResource...) {
+		if resource != "*" && !ramPolicyResourceRegex.MatchString(resource) {
+			errs = append(errs, fmt.Errorf("invalid resource %q, the resource must be * or in the format of acs:<service>:<region>:<account>:<relative_id>", resource))
+		}
+	}
+
+	for operator, conditions := range s.Condition {
+		if !isRamPolicyConditionOperator(operator) {
+			errs = append(errs, fmt.Errorf("unsupported condition operator %q", operator))
+			continue
+		}
+		for key, values := range conditions {
+			if !ramPolicyConditionKeyRegex.MatchString(key) {
+				errs = append(errs, fmt.Errorf("invalid condition key %q, the key must be in the format of <prefix>:<key>", key))
+			}
+			if len(values) == 0 {
+				errs = append(errs, fmt.Errorf("condition %s of %q requires at least one value", operator, key))
+			}
+			for _, value := range values {
+				if err := validateRamPolicyConditionValue(operator, value); err != nil {
+					errs = append(errs, fmt.Errorf("condition %s of %q: %w", operator, key, err))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// json renders the minified policy document.
+func (doc *ramPolicyDocument) json() (string, error) {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(doc); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
+
+// merge replaces the statement with the same Sid, otherwise appends it.
+func (doc *ramPolicyDocument) merge(statements []*ramPolicyStatement) {
+	for _, statement := range statements {
+		replaced := false
+		if statement.Sid != "" {
+			for i, existing := range doc.Statement {
+				if existing.Sid == statement.Sid {
+					doc.Statement[i] = statement
+					replaced = true
+					break
+				}
+			}
+		}
+		if !replaced {
+			doc.Statement = append(doc.Statement, statement)
+		}
+	}
+}
+
+func isRamPolicyConditionOperator(operator string) bool {
+	for _, o := range getRamPolicyConditionOperators() {
+		if o == operator {
+			return true
+		}
+	}
+	return false
+}
+
+func validateRamPolicyConditionValue(operator, value string) error {
+	switch {
+	case strings.HasPrefix(operator, "Numeric"):
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("value %q is not a number", value)
+		}
+	case strings.HasPrefix(operator, "Date"):
+		if _, err := parseRamPolicyDate(value); err != nil {
+			return fmt.Errorf("value %q is not a date in ISO 8601 format", value)
+		}
+	case operator == "Bool":
+		if value != "true" && value != "false" {
+			return fmt.Errorf("value %q is not a boolean", value)
+		}
+	case operator == "IpAddress" || operator == "NotIpAddress":
+		if net.ParseIP(value) == nil {
+			if _, _, err := net.ParseCIDR(value); err != nil {
+				return fmt.Errorf("value %q is not an IP address or CIDR block", value)
+			}
+		}
+	}
+	return nil
+}
+
+func parseRamPolicyDate(value string) (time.Time, error) {
+	var err error
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05Z0700", "2006-01-02"} {
+		var t time.Time
+		if t, err = time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+var (
+	_ datasource.DataSource = &ramPolicyDocumentDataSource{}
+)
+
+func NewRamPolicyDocumentDataSource() datasource.DataSource {
+	return &ramPolicyDocumentDataSource{}
+}
+
+type ramPolicyDocumentDataSource struct{}
+
+type ramPolicyDocumentDataSourceModel struct {
+	SourcePolicyDocuments   types.List                    `tfsdk:"source_policy_documents"`
+	OverridePolicyDocuments types.List                    `tfsdk:"override_policy_documents"`
+	Statement               []*ramPolicyDocumentStatement `tfsdk:"statement"`
+	Json                    types.String                  `tfsdk:"json"`
+	Length                  types.Int64                   `tfsdk:"length"`
+}
+
+type ramPolicyDocumentStatement struct {
+	Sid       types.String                  `tfsdk:"sid"`
+	Effect    types.String                  `tfsdk:"effect"`
+	Actions   types.List                    `tfsdk:"actions"`
+	Resources types.List                    `tfsdk:"resources"`
+	Condition []*ramPolicyDocumentCondition `tfsdk:"condition"`
+}
+
+type ramPolicyDocumentCondition struct {
+	Operator types.String `tfsdk:"operator"`
+	Key      types.String `tfsdk:"key"`
+	Values   types.List   `tfsdk:"values"`
+}
+
+func (d *ramPolicyDocumentDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ram_policy_document"
+}
+
+func (d *ramPolicyDocumentDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source generates a RAM policy document in JSON format and validates " +
+			"its syntax locally, so that it can be safely used by the RAM policy resources.",
+		Attributes: map[string]schema.Attribute{
+			"source_policy_documents": schema.ListAttribute{
+				Description: "List of RAM policy documents in JSON format. The statements of the " +
+					"documents are merged in order, and the statement blocks of this data " +
+					"source will replace the source statements with the same sid.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"override_policy_documents": schema.ListAttribute{
+				Description: "List of RAM policy documents in JSON format. The statements with Sid " +
+					"will replace the statements with the same sid in the merged document, " +
+					"other statements are appended.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"json": schema.StringAttribute{
+				Description: "The minified RAM policy document in JSON format.",
+				Computed:    true,
+			},
+			"length": schema.Int64Attribute{
+				Description: "The character length of the RAM policy document.",
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"statement": schema.ListNestedBlock{
+				Description: "The statements of the RAM policy document.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"sid": schema.StringAttribute{
+							Description: "The identifier of the statement, which is used to merge " +
+								"the statements of the policy documents.",
+							Optional: true,
+						},
+						"effect": schema.StringAttribute{
+							Description: "The effect of the statement. Valid values: Allow, Deny. Default to Allow.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("Allow", "Deny"),
+							},
+						},
+						"actions": schema.ListAttribute{
+							Description: "The actions of the statement, e.g. ecs:DescribeInstances, oss:Get*.",
+							Required:    true,
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+						},
+						"resources": schema.ListAttribute{
+							Description: "The resources of the statement, e.g. acs:oss:*:*:mybucket/*.",
+							Required:    true,
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"condition": schema.ListNestedBlock{
+							Description: "The conditions of the statement.",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"operator": schema.StringAttribute{
+										Description: "The condition operator, e.g. StringEquals, IpAddress, DateLessThan.",
+										Required:    true,
+										Validators: []validator.String{
+											stringvalidator.OneOf(getRamPolicyConditionOperators()...),
+										},
+									},
+									"key": schema.StringAttribute{
+										Description: "The condition key, e.g. acs:SourceIp.",
+										Required:    true,
+									},
+									"values": schema.ListAttribute{
+										Description: "The values of the condition key.",
+										Required:    true,
+										ElementType: types.StringType,
+										Validators: []validator.List{
+											listvalidator.SizeAtLeast(1),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ramPolicyDocumentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan *ramPolicyDocumentDataSourceModel
+	getPlanDiags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policyDocument := &ramPolicyDocument{
+		Version:   "1",
+		Statement: []*ramPolicyStatement{},
+	}
+
+	var sourcePolicyDocuments []string
+	resp.Diagnostics.Append(plan.SourcePolicyDocuments.ElementsAs(ctx, &sourcePolicyDocuments, false)...)
+	var overridePolicyDocuments []string
+	resp.Diagnostics.Append(plan.OverridePolicyDocuments.ElementsAs(ctx, &overridePolicyDocuments, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, document := range sourcePolicyDocuments {
+		sourceDocument, err := parseRamPolicyDocument(document)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("source_policy_documents").AtListIndex(i),
+				"[Input Error] Invalid Policy Document",
+				err.Error(),
+			)
+			continue
+		}
+		policyDocument.Statement = append(policyDocument.Statement, sourceDocument.Statement...)
+	}
+
+	statements := []*ramPolicyStatement{}
+	for _, s := range plan.Statement {
+		statement := &ramPolicyStatement{
+			Sid:       s.Sid.ValueString(),
+			Effect:    "Allow",
+			Action:    ramPolicyStringList{},
+			Resource:  ramPolicyStringList{},
+			Condition: map[string]map[string]ramPolicyStringList{},
+		}
+		if !(s.Effect.IsNull() || s.Effect.IsUnknown()) {
+			statement.Effect = s.Effect.ValueString()
+		}
+		resp.Diagnostics.Append(s.Actions.ElementsAs(ctx, &statement.Action, false)...)
+		resp.Diagnostics.Append(s.Resources.ElementsAs(ctx, &statement.Resource, false)...)
+
+		for _, c := range s.Condition {
+			var values []string
+			resp.Diagnostics.Append(c.Values.ElementsAs(ctx, &values, false)...)
+
+			operator := c.Operator.ValueString()
+			if _, ok := statement.Condition[operator]; !ok {
+				statement.Condition[operator] = map[string]ramPolicyStringList{}
+			}
+			key := c.Key.ValueString()
+			statement.Condition[operator][key] = append(statement.Condition[operator][key], values...)
+		}
+		statements = append(statements, statement)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	policyDocument.merge(statements)
+
+	for i, document := range overridePolicyDocuments {
+		overrideDocument, err := parseRamPolicyDocument(document)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("override_policy_documents").AtListIndex(i),
+				"[Input Error] Invalid Policy Document",
+				err.Error(),
+			)
+			continue
+		}
+		policyDocument.merge(overrideDocument.Statement)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(policyDocument.Statement) == 0 {
+		resp.Diagnostics.AddError(
+			"[Input Error] Empty Policy Document",
+			"At least one statement is required in the policy document.",
+		)
+		return
+	}
+
+	for _, err := range policyDocument.validate() {
+		resp.Diagnostics.AddError(
+			"[Input Error] Invalid Policy Document",
+			err.Error(),
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policyJson, err := policyDocument.json()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[ERROR] Failed to Render Policy Document",
+			err.Error(),
+		)
+		return
+	}
+
+	length := utf8.RuneCountInString(policyJson)
+	if length > maxLength {
+		resp.Diagnostics.AddWarning(
+			"Policy Document Exceeds Maximum Length",
+			fmt.Sprintf("The policy document has %d characters which exceeds the maximum length of "+
+				"%d characters of a RAM policy. Use st-alicloud_ram_policy to split it into "+
+				"multiple policies.", length, maxLength),
+		)
+	}
+
+	state := &ramPolicyDocumentDataSourceModel{
+		SourcePolicyDocuments:   plan.SourcePolicyDocuments,
+		OverridePolicyDocuments: plan.OverridePolicyDocuments,
+		Statement:               plan.Statement,
+		Json:                    types.StringValue(policyJson),
+		Length:                  types.Int64Value(int64(length)),
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,579 @@
+package alicloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudRamClient "github.com/alibabacloud-go/ram-20150501/v2/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+const (
+	ramPolicyDecisionAllowed      = "allowed"
+	ramPolicyDecisionExplicitDeny = "explicitDeny"
+	ramPolicyDecisionImplicitDeny = "implicitDeny"
+)
+
+// RAM policy attached to a user, either directly or through a group.
+type ramAttachedPolicy struct {
+	PolicyName string
+	PolicyType string
+	// Source is "user" for the policies attached to the user directly, or
+	// "group:<group_name>" for the policies attached to the user's groups.
+	Source   string
+	Document *ramPolicyDocument
+}
+
+// ramPolicySimulationRequest is a single action and resource pair to be evaluated.
+type ramPolicySimulationRequest struct {
+	Action   string
+	Resource string
+	Context  map[string]string
+}
+
+type ramPolicySimulationResult struct {
+	Decision  string
+	Policy    *ramAttachedPolicy
+	Statement *ramPolicyStatement
+}
+
+// evaluateRamPolicies evaluates the request against the policies following
+// the RAM rules: an explicit deny in any statement wins, otherwise an allow
+// in any statement allows the request, otherwise the request is denied implicitly.
+func evaluateRamPolicies(policies []*ramAttachedPolicy, request *ramPolicySimulationRequest) *ramPolicySimulationResult {
+	result := &ramPolicySimulationResult{
+		Decision: ramPolicyDecisionImplicitDeny,
+	}
+
+	for _, policy := range policies {
+		for _, statement := range policy.Document.Statement {
+			if !statement.matches(request) {
+				continue
+			}
+			if statement.Effect == "Deny" {
+				return &ramPolicySimulationResult{
+					Decision:  ramPolicyDecisionExplicitDeny,
+					Policy:    policy,
+					Statement: statement,
+				}
+			}
+			if result.Decision == ramPolicyDecisionImplicitDeny {
+				result.Decision = ramPolicyDecisionAllowed
+				result.Policy = policy
+				result.Statement = statement
+			}
+		}
+	}
+	return result
+}
+
+// matches returns whether the statement applies to the request. A statement
+// with NotAction or NotResource applies to the actions or the resources that
+// do not match any of the listed ones.
+func (s *ramPolicyStatement) matches(request *ramPolicySimulationRequest) bool {
+	// Actions are case insensitive in RAM.
+	matchAction := func(action string) bool {
+		return ramPolicyWildcardMatch(strings.ToLower(action), strings.ToLower(request.Action))
+	}
+	if len(s.NotAction) > 0 {
+		if matchRamPolicyAny(s.NotAction, matchAction) {
+			return false
+		}
+	} else if !matchRamPolicyAny(s.Action, matchAction) {
+		return false
+	}
+
+	matchResource := func(resource string) bool {
+		return ramPolicyWildcardMatch(resource, request.Resource)
+	}
+	if len(s.NotResource) > 0 {
+		if matchRamPolicyAny(s.NotResource, matchResource) {
+			return false
+		}
+	} else if !matchRamPolicyAny(s.Resource, matchResource) {
+		return false
+	}
+
+	// All the conditions must be satisfied, while any value of a condition key
+	// satisfies the condition. A negated operator is satisfied only when none
+	// of the values satisfies its positive operator. A condition key missing
+	// from the context satisfies no value, so only the negated operators are
+	// satisfied.
+	for operator, conditions := range s.Condition {
+		positiveOperator, negated := ramPolicyNegatedOperators[operator]
+		if !negated {
+			positiveOperator = operator
+		}
+		for key, values := range conditions {
+			satisfied := false
+			if contextValue, ok := lookupRamPolicyContext(request.Context, key); ok {
+				satisfied = matchRamPolicyAny(values, func(value string) bool {
+					return evaluateRamPolicyCondition(positiveOperator, value, contextValue)
+				})
+			}
+			if satisfied == negated {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func matchRamPolicyAny(values []string, match func(string) bool) bool {
+	for _, value := range values {
+		if match(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ramPolicyNegatedOperators maps the negated condition operators to their
+// positive operators.
+var ramPolicyNegatedOperators = map[string]string{
+	"StringNotEquals":           "StringEquals",
+	"StringNotEqualsIgnoreCase": "StringEqualsIgnoreCase",
+	"StringNotLike":             "StringLike",
+	"NotIpAddress":              "IpAddress",
+	"NumericNotEquals":          "NumericEquals",
+	"DateNotEquals":             "DateEquals",
+}
+
+// Condition keys are case insensitive in RAM.
+func lookupRamPolicyContext(context map[string]string, key string) (string, bool) {
+	for k, v := range context {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func evaluateRamPolicyCondition(operator, conditionValue, contextValue string) bool {
+	switch operator {
+	case "StringEquals":
+		return contextValue == conditionValue
+	case "StringEqualsIgnoreCase":
+		return strings.EqualFold(contextValue, conditionValue)
+	case "StringLike":
+		return ramPolicyWildcardMatch(conditionValue, contextValue)
+	case "Bool":
+		return strings.EqualFold(contextValue, conditionValue)
+	case "IpAddress":
+		ip := net.ParseIP(contextValue)
+		if ip == nil {
+			return false
+		}
+		if _, cidr, err := net.ParseCIDR(conditionValue); err == nil {
+			return cidr.Contains(ip)
+		}
+		return ip.Equal(net.ParseIP(conditionValue))
+	}
+
+	if strings.HasPrefix(operator, "Numeric") {
+		condition, err := strconv.ParseFloat(conditionValue, 64)
+		if err != nil {
+			return false
+		}
+		value, err := strconv.ParseFloat(contextValue, 64)
+		if err != nil {
+			return false
+		}
+		return compareRamPolicyCondition(strings.TrimPrefix(operator, "Numeric"), value-condition)
+	}
+
+	if strings.HasPrefix(operator, "Date") {
+		condition, err := parseRamPolicyDate(conditionValue)
+		if err != nil {
+			return false
+		}
+		value, err := parseRamPolicyDate(contextValue)
+		if err != nil {
+			return false
+		}
+		return compareRamPolicyCondition(strings.TrimPrefix(operator, "Date"), float64(value.Sub(condition)))
+	}
+
+	return false
+}
+
+// compareRamPolicyCondition compares the difference between the context value
+// and the condition value with the comparison of a numeric or date operator.
+func compareRamPolicyCondition(comparison string, difference float64) bool {
+	switch comparison {
+	case "Equals":
+		return difference == 0
+	case "LessThan":
+		return difference < 0
+	case "LessThanEquals":
+		return difference <= 0
+	case "GreaterThan":
+		return difference > 0
+	case "GreaterThanEquals":
+		return difference >= 0
+	}
+	return false
+}
+
+// ramPolicyWildcardMatch matches the value with the pattern, where * matches
+// any sequence of characters and ? matches any single character.
+func ramPolicyWildcardMatch(pattern, value string) bool {
+	p, v := []rune(pattern), []rune(value)
+	pIdx, vIdx := 0, 0
+	starIdx, matchIdx := -1, 0
+
+	for vIdx < len(v) {
+		if pIdx < len(p) && (p[pIdx] == '?' || p[pIdx] == v[vIdx]) {
+			pIdx++
+			vIdx++
+		} else if pIdx < len(p) && p[pIdx] == '*' {
+			starIdx = pIdx
+			matchIdx = vIdx
+			pIdx++
+		} else if starIdx != -1 {
+			pIdx = starIdx + 1
+			matchIdx++
+			vIdx = matchIdx
+		} else {
+			return false
+		}
+	}
+
+	for pIdx < len(p) && p[pIdx] == '*' {
+		pIdx++
+	}
+	return pIdx == len(p)
+}
+
+// getRamUserAttachedPolicies returns the policies attached to the user directly
+// and through the user's groups, together with their default version documents.
+func getRamUserAttachedPolicies(client *alicloudRamClient.Client, userName string) ([]*ramAttachedPolicy, error) {
+	attachedPolicies, err := listRamUserPolicies(client, userName)
+	if err != nil {
+		return nil, err
+	}
+
+	groupNames, err := listRamUserGroups(client, userName)
+	if err != nil {
+		return nil, err
+	}
+	for _, groupName := range groupNames {
+		groupPolicies, err := listRamGroupPolicies(client, groupName)
+		if err != nil {
+			return nil, err
+		}
+		attachedPolicies = append(attachedPolicies, groupPolicies...)
+	}
+
+	// The same policy may be attached to the user and the user's groups, only
+	// fetch its document once.
+	documents := map[string]*ramPolicyDocument{}
+	for _, policy := range attachedPolicies {
+		key := policy.PolicyType + ":" + policy.PolicyName
+		if document, ok := documents[key]; ok {
+			policy.Document = document
+			continue
+		}
+
+		policyDocument, err := getRamPolicyDefaultDocument(client, policy.PolicyName, policy.PolicyType)
+		if err != nil {
+			return nil, err
+		}
+		document, err := parseRamPolicyDocument(policyDocument)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the policy document of %s: %w", policy.PolicyName, err)
+		}
+		policy.Document = document
+		documents[key] = document
+	}
+
+	return attachedPolicies, nil
+}
+
+// getRamPolicyDefaultDocument returns the document of the default version of the policy.
+func getRamPolicyDefaultDocument(client *alicloudRamClient.Client, policyName, policyType string) (policyDocument string, err error) {
+	getPolicy := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		getPolicyRequest := &alicloudRamClient.GetPolicyRequest{
+			PolicyName: tea.String(policyName),
+			PolicyType: tea.String(policyType),
+		}
+		getPolicyResponse, err := client.GetPolicyWithOptions(getPolicyRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		if getPolicyResponse.Body.DefaultPolicyVersion == nil || getPolicyResponse.Body.DefaultPolicyVersion.PolicyDocument == nil {
+			return backoff.Permanent(fmt.Errorf("could not find the default version of policy: %s", policyName))
+		}
+		policyDocument = *getPolicyResponse.Body.DefaultPolicyVersion.PolicyDocument
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(getPolicy, reconnectBackoff)
+	return
+}
+
+var (
+	_ datasource.DataSource              = &ramPolicySimulationDataSource{}
+	_ datasource.DataSourceWithConfigure = &ramPolicySimulationDataSource{}
+)
+
+func NewRamPolicySimulationDataSource() datasource.DataSource {
+	return &ramPolicySimulationDataSource{}
+}
+
+type ramPolicySimulationDataSource struct {
+	client *alicloudRamClient.Client
+}
+
+type ramPolicySimulationDataSourceModel struct {
+	PolicyDocuments types.List                          `tfsdk:"policy_documents"`
+	UserName        types.String                        `tfsdk:"user_name"`
+	Request         []*ramPolicySimulationRequestModel  `tfsdk:"request"`
+	AllAllowed      types.Bool                          `tfsdk:"all_allowed"`
+	Results         []*ramPolicySimulationResultsDetail `tfsdk:"results"`
+}
+
+type ramPolicySimulationRequestModel struct {
+	Action   types.String `tfsdk:"action"`
+	Resource types.String `tfsdk:"resource"`
+	Context  types.Map    `tfsdk:"context"`
+}
+
+type ramPolicySimulationResultsDetail struct {
+	Action           types.String `tfsdk:"action"`
+	Resource         types.String `tfsdk:"resource"`
+	Decision         types.String `tfsdk:"decision"`
+	Allowed          types.Bool   `tfsdk:"allowed"`
+	MatchedPolicy    types.String `tfsdk:"matched_policy"`
+	MatchedSource    types.String `tfsdk:"matched_source"`
+	MatchedStatement types.String `tfsdk:"matched_statement"`
+}
+
+func (d *ramPolicySimulationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ram_policy_simulation"
+}
+
+func (d *ramPolicySimulationDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source evaluates RAM policies locally against a list of action and " +
+			"resource pairs, following the RAM rules where an explicit deny always wins.",
+		Attributes: map[string]schema.Attribute{
+			"policy_documents": schema.ListAttribute{
+				Description: "List of RAM policy documents in JSON format to be evaluated.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"user_name": schema.StringAttribute{
+				Description: "The name of the RAM user. The policies attached to the user " +
+					"and the user's groups will be evaluated together with policy_documents.",
+				Optional: true,
+			},
+			"all_allowed": schema.BoolAttribute{
+				Description: "Whether all the requests are allowed.",
+				Computed:    true,
+			},
+			"results": schema.ListNestedAttribute{
+				Description: "The evaluation results, in the same order as the request blocks.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"action": schema.StringAttribute{
+							Description: "The action of the request.",
+							Computed:    true,
+						},
+						"resource": schema.StringAttribute{
+							Description: "The resource of the request.",
+							Computed:    true,
+						},
+						"decision": schema.StringAttribute{
+							Description: "The decision of the request. Valid values: allowed, explicitDeny, implicitDeny.",
+							Computed:    true,
+						},
+						"allowed": schema.BoolAttribute{
+							Description: "Whether the request is allowed.",
+							Computed:    true,
+						},
+						"matched_policy": schema.StringAttribute{
+							Description: "The name of the policy that decides the request. Policies from " +
+								"policy_documents are named by their index, e.g. policy_documents[0].",
+							Computed: true,
+						},
+						"matched_source": schema.StringAttribute{
+							Description: "Where the matched policy is attached, e.g. user for the user itself or group:devops for a group.",
+							Computed:    true,
+						},
+						"matched_statement": schema.StringAttribute{
+							Description: "The statement that decides the request in JSON format.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"request": schema.ListNestedBlock{
+				Description: "The action and resource pairs to be evaluated.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"action": schema.StringAttribute{
+							Description: "The action of the request, e.g. oss:GetObject.",
+							Required:    true,
+						},
+						"resource": schema.StringAttribute{
+							Description: "The resource of the request, e.g. acs:oss:*:*:mybucket/file.",
+							Required:    true,
+						},
+						"context": schema.MapAttribute{
+							Description: "The condition keys and values of the request, e.g. " +
+								"acs:SourceIp. Conditions with keys not in the context are not satisfied, except the " +
+								"negated conditions such as StringNotEquals and NotIpAddress.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ramPolicySimulationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(alicloudClients).ramClient
+}
+
+func (d *ramPolicySimulationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan *ramPolicySimulationDataSourceModel
+	getPlanDiags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.PolicyDocuments.IsNull() && plan.UserName.IsNull() {
+		resp.Diagnostics.AddError(
+			"[Input Error] Missing Policies",
+			"Either policy_documents or user_name must be configured.",
+		)
+		return
+	}
+
+	policies := []*ramAttachedPolicy{}
+
+	var policyDocuments []string
+	resp.Diagnostics.Append(plan.PolicyDocuments.ElementsAs(ctx, &policyDocuments, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i, document := range policyDocuments {
+		policyDocument, err := parseRamPolicyDocument(document)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("policy_documents").AtListIndex(i),
+				"[Input Error] Invalid Policy Document",
+				err.Error(),
+			)
+			continue
+		}
+		policies = append(policies, &ramAttachedPolicy{
+			PolicyName: fmt.Sprintf("policy_documents[%d]", i),
+			Document:   policyDocument,
+		})
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !(plan.UserName.IsNull() || plan.UserName.IsUnknown()) {
+		userPolicies, err := getRamUserAttachedPolicies(d.client, plan.UserName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Read Policies for User",
+				err.Error(),
+			)
+			return
+		}
+		policies = append(policies, userPolicies...)
+	}
+
+	state := &ramPolicySimulationDataSourceModel{
+		PolicyDocuments: plan.PolicyDocuments,
+		UserName:        plan.UserName,
+		Request:         plan.Request,
+		AllAllowed:      types.BoolValue(true),
+		Results:         []*ramPolicySimulationResultsDetail{},
+	}
+
+	for _, r := range plan.Request {
+		request := &ramPolicySimulationRequest{
+			Action:   r.Action.ValueString(),
+			Resource: r.Resource.ValueString(),
+			Context:  map[string]string{},
+		}
+		resp.Diagnostics.Append(r.Context.ElementsAs(ctx, &request.Context, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		result := evaluateRamPolicies(policies, request)
+		resultDetail := &ramPolicySimulationResultsDetail{
+			Action:           types.StringValue(request.Action),
+			Resource:         types.StringValue(request.Resource),
+			Decision:         types.StringValue(result.Decision),
+			Allowed:          types.BoolValue(result.Decision == ramPolicyDecisionAllowed),
+			MatchedPolicy:    types.StringNull(),
+			MatchedSource:    types.StringNull(),
+			MatchedStatement: types.StringNull(),
+		}
+		if result.Statement != nil {
+			statementJson, err := json.Marshal(result.Statement)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"[ERROR] Failed to Render Policy Statement",
+					err.Error(),
+				)
+				return
+			}
+
+			resultDetail.MatchedPolicy = types.StringValue(result.Policy.PolicyName)
+			resultDetail.MatchedStatement = types.StringValue(string(statementJson))
+			if result.Policy.Source != "" {
+				resultDetail.MatchedSource = types.StringValue(result.Policy.Source)
+			}
+		}
+		if result.Decision != ramPolicyDecisionAllowed {
+			state.AllAllowed = types.BoolValue(false)
+		}
+		state.Results = append(state.Results, resultDetail)
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
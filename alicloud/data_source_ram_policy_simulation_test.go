@@ -0,0 +1,170 @@
+package alicloud
+
+import (
+	"testing"
+)
+
+func TestEvaluateRamPolicies(t *testing.T) {
+	policies := func(documents ...string) []*ramAttachedPolicy {
+		attachedPolicies := []*ramAttachedPolicy{}
+		for _, document := range documents {
+			policyDocument, err := parseRamPolicyDocument(document)
+			if err != nil {
+				t.Fatalf("failed to parse the policy document %s: %v", document, err)
+			}
+			attachedPolicies = append(attachedPolicies, &ramAttachedPolicy{
+				PolicyName: "test",
+				PolicyType: "Custom",
+				Source:     "user",
+				Document:   policyDocument,
+			})
+		}
+		return attachedPolicies
+	}
+
+	allowAll := `{"Version":"1","Statement":[{"Effect":"Allow","Action":"*","Resource":"*"}]}`
+
+	testCases := []struct {
+		name     string
+		policies []*ramAttachedPolicy
+		request  *ramPolicySimulationRequest
+		decision string
+	}{
+		{
+			name:     "no policy",
+			policies: policies(),
+			request:  &ramPolicySimulationRequest{Action: "ecs:DescribeInstances", Resource: "*"},
+			decision: ramPolicyDecisionImplicitDeny,
+		},
+		{
+			name:     "allow with wildcard action in a different case",
+			policies: policies(`{"Version":"1","Statement":[{"Effect":"Allow","Action":"ECS:Describe*","Resource":"*"}]}`),
+			request:  &ramPolicySimulationRequest{Action: "ecs:DescribeInstances", Resource: "acs:ecs:*:*:instance/i-1"},
+			decision: ramPolicyDecisionAllowed,
+		},
+		{
+			name:     "resource not matched",
+			policies: policies(`{"Version":"1","Statement":[{"Effect":"Allow","Action":"oss:GetObject","Resource":"acs:oss:*:*:a/*"}]}`),
+			request:  &ramPolicySimulationRequest{Action: "oss:GetObject", Resource: "acs:oss:*:*:b/key"},
+			decision: ramPolicyDecisionImplicitDeny,
+		},
+		{
+			name: "explicit deny wins",
+			policies: policies(allowAll,
+				`{"Version":"1","Statement":[{"Effect":"Deny","Action":"ram:*","Resource":"*"}]}`),
+			request:  &ramPolicySimulationRequest{Action: "ram:CreateUser", Resource: "*"},
+			decision: ramPolicyDecisionExplicitDeny,
+		},
+		{
+			name: "deny with not action",
+			policies: policies(allowAll,
+				`{"Version":"1","Statement":[{"Effect":"Deny","NotAction":["ecs:*","oss:*"],"Resource":"*"}]}`),
+			request:  &ramPolicySimulationRequest{Action: "ram:CreateUser", Resource: "*"},
+			decision: ramPolicyDecisionExplicitDeny,
+		},
+		{
+			name: "deny with not action on a listed action",
+			policies: policies(allowAll,
+				`{"Version":"1","Statement":[{"Effect":"Deny","NotAction":["ecs:*","oss:*"],"Resource":"*"}]}`),
+			request:  &ramPolicySimulationRequest{Action: "oss:GetObject", Resource: "*"},
+			decision: ramPolicyDecisionAllowed,
+		},
+		{
+			name: "deny with not resource",
+			policies: policies(allowAll,
+				`{"Version":"1","Statement":[{"Effect":"Deny","Action":"oss:*","NotResource":"acs:oss:*:*:public/*"}]}`),
+			request:  &ramPolicySimulationRequest{Action: "oss:GetObject", Resource: "acs:oss:*:*:private/key"},
+			decision: ramPolicyDecisionExplicitDeny,
+		},
+		{
+			name: "deny with not resource on a listed resource",
+			policies: policies(allowAll,
+				`{"Version":"1","Statement":[{"Effect":"Deny","Action":"oss:*","NotResource":"acs:oss:*:*:public/*"}]}`),
+			request:  &ramPolicySimulationRequest{Action: "oss:GetObject", Resource: "acs:oss:*:*:public/key"},
+			decision: ramPolicyDecisionAllowed,
+		},
+		{
+			name:     "ip address in cidr",
+			policies: policies(`{"Version":"1","Statement":[{"Effect":"Allow","Action":"*","Resource":"*","Condition":{"IpAddress":{"acs:SourceIp":["10.0.0.0/8","192.168.0.1"]}}}]}`),
+			request:  &ramPolicySimulationRequest{Action: "ecs:StartInstance", Resource: "*", Context: map[string]string{"acs:sourceip": "10.1.2.3"}},
+			decision: ramPolicyDecisionAllowed,
+		},
+		{
+			name:     "ip address not in cidr",
+			policies: policies(`{"Version":"1","Statement":[{"Effect":"Allow","Action":"*","Resource":"*","Condition":{"IpAddress":{"acs:SourceIp":["10.0.0.0/8","192.168.0.1"]}}}]}`),
+			request:  &ramPolicySimulationRequest{Action: "ecs:StartInstance", Resource: "*", Context: map[string]string{"acs:SourceIp": "172.16.0.1"}},
+			decision: ramPolicyDecisionImplicitDeny,
+		},
+		{
+			name:     "missing key of positive operator",
+			policies: policies(`{"Version":"1","Statement":[{"Effect":"Allow","Action":"*","Resource":"*","Condition":{"Bool":{"acs:MFAPresent":"true"}}}]}`),
+			request:  &ramPolicySimulationRequest{Action: "ecs:StartInstance", Resource: "*"},
+			decision: ramPolicyDecisionImplicitDeny,
+		},
+		{
+			name: "missing key of negated operator",
+			policies: policies(allowAll,
+				`{"Version":"1","Statement":[{"Effect":"Deny","Action":"*","Resource":"*","Condition":{"NotIpAddress":{"acs:SourceIp":"10.0.0.0/8"}}}]}`),
+			request:  &ramPolicySimulationRequest{Action: "ecs:StartInstance", Resource: "*"},
+			decision: ramPolicyDecisionExplicitDeny,
+		},
+		{
+			name: "negated operator matching one of the values",
+			policies: policies(allowAll,
+				`{"Version":"1","Statement":[{"Effect":"Deny","Action":"*","Resource":"*","Condition":{"StringNotEquals":{"acs:CurrentRegion":["cn-hangzhou","cn-shanghai"]}}}]}`),
+			request:  &ramPolicySimulationRequest{Action: "ecs:StartInstance", Resource: "*", Context: map[string]string{"acs:CurrentRegion": "cn-shanghai"}},
+			decision: ramPolicyDecisionAllowed,
+		},
+		{
+			name: "negated operator matching none of the values",
+			policies: policies(allowAll,
+				`{"Version":"1","Statement":[{"Effect":"Deny","Action":"*","Resource":"*","Condition":{"StringNotEquals":{"acs:CurrentRegion":["cn-hangzhou","cn-shanghai"]}}}]}`),
+			request:  &ramPolicySimulationRequest{Action: "ecs:StartInstance", Resource: "*", Context: map[string]string{"acs:CurrentRegion": "us-west-1"}},
+			decision: ramPolicyDecisionExplicitDeny,
+		},
+		{
+			name:     "numeric and date conditions",
+			policies: policies(`{"Version":"1","Statement":[{"Effect":"Allow","Action":"*","Resource":"*","Condition":{"NumericLessThanEquals":{"acs:MaxKeys":"100"},"DateLessThan":{"acs:CurrentTime":"2030-01-01T00:00:00Z"}}}]}`),
+			request:  &ramPolicySimulationRequest{Action: "oss:ListObjects", Resource: "*", Context: map[string]string{"acs:MaxKeys": "100", "acs:CurrentTime": "2029-12-31T23:59:59Z"}},
+			decision: ramPolicyDecisionAllowed,
+		},
+		{
+			name:     "numeric condition not satisfied",
+			policies: policies(`{"Version":"1","Statement":[{"Effect":"Allow","Action":"*","Resource":"*","Condition":{"NumericLessThanEquals":{"acs:MaxKeys":"100"}}}]}`),
+			request:  &ramPolicySimulationRequest{Action: "oss:ListObjects", Resource: "*", Context: map[string]string{"acs:MaxKeys": "101"}},
+			decision: ramPolicyDecisionImplicitDeny,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result := evaluateRamPolicies(testCase.policies, testCase.request)
+			if result.Decision != testCase.decision {
+				t.Errorf("expected decision %s, got %s", testCase.decision, result.Decision)
+			}
+		})
+	}
+}
+
+func TestRamPolicyWildcardMatch(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		value   string
+		matched bool
+	}{
+		{pattern: "*", value: "", matched: true},
+		{pattern: "*", value: "acs:oss:*:*:bucket/key", matched: true},
+		{pattern: "acs:oss:*:*:bucket/*", value: "acs:oss:*:*:bucket/a/b", matched: true},
+		{pattern: "acs:oss:*:*:bucket/*", value: "acs:oss:*:*:other/a", matched: false},
+		{pattern: "ecs:Describe?nstances", value: "ecs:DescribeInstances", matched: true},
+		{pattern: "ecs:Describe?", value: "ecs:Describe", matched: false},
+		{pattern: "a*b*c", value: "aXXbYYc", matched: true},
+		{pattern: "a*b*c", value: "aXXbYY", matched: false},
+	}
+
+	for _, testCase := range testCases {
+		if matched := ramPolicyWildcardMatch(testCase.pattern, testCase.value); matched != testCase.matched {
+			t.Errorf("expected %q matching %q to be %t, got %t", testCase.pattern, testCase.value, testCase.matched, matched)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package alicloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudRamClient "github.com/alibabacloud-go/ram-20150501/v2/client"
+)
+
+var (
+	_ datasource.DataSource              = &ramUserEffectivePoliciesDataSource{}
+	_ datasource.DataSourceWithConfigure = &ramUserEffectivePoliciesDataSource{}
+)
+
+func NewRamUserEffectivePoliciesDataSource() datasource.DataSource {
+	return &ramUserEffectivePoliciesDataSource{}
+}
+
+type ramUserEffectivePoliciesDataSource struct {
+	client *alicloudRamClient.Client
+}
+
+type ramUserEffectivePoliciesDataSourceModel struct {
+	UserName         types.String                       `tfsdk:"user_name"`
+	PolicyNamePrefix types.String                       `tfsdk:"policy_name_prefix"`
+	Policies         []*ramUserEffectivePolicy          `tfsdk:"policies"`
+	Statements       []*ramUserEffectivePolicyStatement `tfsdk:"statements"`
+	Json             types.String                       `tfsdk:"json"`
+}
+
+type ramUserEffectivePolicy struct {
+	PolicyName types.String `tfsdk:"policy_name"`
+	PolicyType types.String `tfsdk:"policy_type"`
+	Source     types.String `tfsdk:"source"`
+	Combined   types.Bool   `tfsdk:"combined"`
+	Document   types.String `tfsdk:"document"`
+}
+
+type ramUserEffectivePolicyStatement struct {
+	Effect    types.String `tfsdk:"effect"`
+	Actions   types.List   `tfsdk:"actions"`
+	Resources types.List   `tfsdk:"resources"`
+	Condition types.String `tfsdk:"condition"`
+	Sources   types.List   `tfsdk:"sources"`
+}
+
+func (d *ramUserEffectivePoliciesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ram_user_effective_policies"
+}
+
+func (d *ramUserEffectivePoliciesDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source resolves every policy that applies to a RAM user, including the policies " +
+			"attached to the user directly, the policies attached to the user's groups and the combined policies " +
+			"created by the RAM policy resource, and merges their statements.",
+		Attributes: map[string]schema.Attribute{
+			"user_name": schema.StringAttribute{
+				Description: "The name of the RAM user.",
+				Required:    true,
+			},
+			"policy_name_prefix": schema.StringAttribute{
+				Description: "The policy name prefix of the combined policies created by the RAM policy resource. " +
+					"Default to user_name.",
+				Optional: true,
+			},
+			"policies": schema.ListNestedAttribute{
+				Description: "A list of the policies that apply to the user.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"policy_name": schema.StringAttribute{
+							Description: "Name of the policy.",
+							Computed:    true,
+						},
+						"policy_type": schema.StringAttribute{
+							Description: "Type of the policy.",
+							Computed:    true,
+						},
+						"source": schema.StringAttribute{
+							Description: "Where the policy is attached, e.g. user for the user itself or group:devops for a group.",
+							Computed:    true,
+						},
+						"combined": schema.BoolAttribute{
+							Description: "Whether the policy is a combined policy created by the RAM policy resource.",
+							Computed:    true,
+						},
+						"document": schema.StringAttribute{
+							Description: "The document of the default version of the policy.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"statements": schema.ListNestedAttribute{
+				Description: "The deduplicated statements of all the policies.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"effect": schema.StringAttribute{
+							Description: "The effect of the statement.",
+							Computed:    true,
+						},
+						"actions": schema.ListAttribute{
+							Description: "The actions of the statement.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"resources": schema.ListAttribute{
+							Description: "The resources of the statement.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"condition": schema.StringAttribute{
+							Description: "The condition of the statement in JSON format.",
+							Computed:    true,
+						},
+						"sources": schema.ListAttribute{
+							Description: "The policies that contain the statement, in the format of source/policy type/policy name, " +
+								"e.g. group:devops/System/ReadOnlyAccess.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+			"json": schema.StringAttribute{
+				Description: "The merged policy document of the deduplicated statements in JSON format.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *ramUserEffectivePoliciesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(alicloudClients).ramClient
+}
+
+func (d *ramUserEffectivePoliciesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan *ramUserEffectivePoliciesDataSourceModel
+	getPlanDiags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policyNamePrefix := plan.UserName.ValueString()
+	if !(plan.PolicyNamePrefix.IsNull() || plan.PolicyNamePrefix.IsUnknown()) {
+		policyNamePrefix = plan.PolicyNamePrefix.ValueString()
+	}
+	combinedPolicyNameRegex := regexp.MustCompile("^" + regexp.QuoteMeta(policyNamePrefix) + `-\d+$`)
+
+	policies, err := getRamUserAttachedPolicies(d.client, plan.UserName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read Policies for User",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &ramUserEffectivePoliciesDataSourceModel{
+		UserName:         plan.UserName,
+		PolicyNamePrefix: plan.PolicyNamePrefix,
+		Policies:         []*ramUserEffectivePolicy{},
+		Statements:       []*ramUserEffectivePolicyStatement{},
+	}
+
+	merged := &ramPolicyDocument{
+		Version:   "1",
+		Statement: []*ramPolicyStatement{},
+	}
+	// The sources of the merged statements, keyed by the normalized statement.
+	statementSources := map[string][]string{}
+	statementKeys := []string{}
+
+	for _, policy := range policies {
+		document, err := policy.Document.json()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[ERROR] Failed to Render Policy Document",
+				err.Error(),
+			)
+			return
+		}
+
+		state.Policies = append(state.Policies, &ramUserEffectivePolicy{
+			PolicyName: types.StringValue(policy.PolicyName),
+			PolicyType: types.StringValue(policy.PolicyType),
+			Source:     types.StringValue(policy.Source),
+			Combined:   types.BoolValue(policy.Source == "user" && policy.PolicyType == "Custom" && combinedPolicyNameRegex.MatchString(policy.PolicyName)),
+			Document:   types.StringValue(document),
+		})
+
+		for _, statement := range policy.Document.Statement {
+			normalized := normalizeRamPolicyStatement(statement)
+			key, err := json.Marshal(normalized)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"[ERROR] Failed to Render Policy Statement",
+					err.Error(),
+				)
+				return
+			}
+
+			if _, ok := statementSources[string(key)]; !ok {
+				merged.Statement = append(merged.Statement, normalized)
+				statementKeys = append(statementKeys, string(key))
+			}
+			statementSources[string(key)] = append(statementSources[string(key)],
+				fmt.Sprintf("%s/%s/%s", policy.Source, policy.PolicyType, policy.PolicyName))
+		}
+	}
+
+	for i, statement := range merged.Statement {
+		actions, diags := types.ListValueFrom(ctx, types.StringType, []string(statement.Action))
+		resp.Diagnostics.Append(diags...)
+		resources, diags := types.ListValueFrom(ctx, types.StringType, []string(statement.Resource))
+		resp.Diagnostics.Append(diags...)
+		sources, diags := types.ListValueFrom(ctx, types.StringType, statementSources[statementKeys[i]])
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		condition := types.StringNull()
+		if len(statement.Condition) > 0 {
+			conditionJson, err := json.Marshal(statement.Condition)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"[ERROR] Failed to Render Policy Statement",
+					err.Error(),
+				)
+				return
+			}
+			condition = types.StringValue(string(conditionJson))
+		}
+
+		state.Statements = append(state.Statements, &ramUserEffectivePolicyStatement{
+			Effect:    types.StringValue(statement.Effect),
+			Actions:   actions,
+			Resources: resources,
+			Condition: condition,
+			Sources:   sources,
+		})
+	}
+
+	mergedJson, err := merged.json()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[ERROR] Failed to Render Policy Document",
+			err.Error(),
+		)
+		return
+	}
+	state.Json = types.StringValue(mergedJson)
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// normalizeRamPolicyStatement returns a copy of the statement without Sid and
+// with sorted actions, resources and condition values, so that the same
+// statement in different policies can be deduplicated.
+func normalizeRamPolicyStatement(statement *ramPolicyStatement) *ramPolicyStatement {
+	sortedCopy := func(list ramPolicyStringList) ramPolicyStringList {
+		sorted := append(ramPolicyStringList{}, list...)
+		sort.Strings(sorted)
+		return sorted
+	}
+
+	normalized := &ramPolicyStatement{
+		Effect:      statement.Effect,
+		Principal:   statement.Principal,
+		Action:      sortedCopy(statement.Action),
+		NotAction:   sortedCopy(statement.NotAction),
+		Resource:    sortedCopy(statement.Resource),
+		NotResource: sortedCopy(statement.NotResource),
+	}
+	if len(statement.Condition) > 0 {
+		normalized.Condition = map[string]map[string]ramPolicyStringList{}
+		for operator, conditions := range statement.Condition {
+			normalized.Condition[operator] = map[string]ramPolicyStringList{}
+			for key, values := range conditions {
+				normalized.Condition[operator][key] = sortedCopy(values)
+			}
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,305 @@
+package alicloud
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudRamClient "github.com/alibabacloud-go/ram-20150501/v2/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ datasource.DataSource              = &ramUsersDataSource{}
+	_ datasource.DataSourceWithConfigure = &ramUsersDataSource{}
+)
+
+func NewRamUsersDataSource() datasource.DataSource {
+	return &ramUsersDataSource{}
+}
+
+type ramUsersDataSource struct {
+	client *alicloudRamClient.Client
+}
+
+type ramUsersDataSourceModel struct {
+	NameRegex  types.String `tfsdk:"name_regex"`
+	GroupName  types.String `tfsdk:"group_name"`
+	PolicyName types.String `tfsdk:"policy_name"`
+	PolicyType types.String `tfsdk:"policy_type"`
+	Names      types.List   `tfsdk:"names"`
+	Users      []*ramUser   `tfsdk:"users"`
+}
+
+type ramUser struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Comments    types.String `tfsdk:"comments"`
+	CreateDate  types.String `tfsdk:"create_date"`
+	UpdateDate  types.String `tfsdk:"update_date"`
+}
+
+func (d *ramUsersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ram_users"
+}
+
+func (d *ramUsersDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source provides the RAM users of the current AliCloud user.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Description: "A regex string to filter the users by name.",
+				Optional:    true,
+			},
+			"group_name": schema.StringAttribute{
+				Description: "Filter the users that belong to the group.",
+				Optional:    true,
+			},
+			"policy_name": schema.StringAttribute{
+				Description: "Filter the users that the policy is attached to.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("policy_type")),
+				},
+			},
+			"policy_type": schema.StringAttribute{
+				Description: "The type of the policy filter. Valid values: System, Custom.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("System", "Custom"),
+					stringvalidator.AlsoRequires(path.MatchRoot("policy_name")),
+				},
+			},
+			"names": schema.ListAttribute{
+				Description: "A list of the names of the users.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"users": schema.ListNestedAttribute{
+				Description: "A list of RAM users.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "ID of the user.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the user.",
+							Computed:    true,
+						},
+						"display_name": schema.StringAttribute{
+							Description: "Display name of the user.",
+							Computed:    true,
+						},
+						"comments": schema.StringAttribute{
+							Description: "Comments of the user.",
+							Computed:    true,
+						},
+						"create_date": schema.StringAttribute{
+							Description: "Create date of the user.",
+							Computed:    true,
+						},
+						"update_date": schema.StringAttribute{
+							Description: "Update date of the user.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ramUsersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(alicloudClients).ramClient
+}
+
+func (d *ramUsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan *ramUsersDataSourceModel
+	getPlanDiags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !(plan.NameRegex.IsNull() || plan.NameRegex.IsUnknown()) {
+		r, err := regexp.Compile(plan.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"[Input Error] Invalid Name Regex",
+				err.Error(),
+			)
+			return
+		}
+		nameRegex = r
+	}
+
+	// The names of the users that match the principal filters, nil means no
+	// principal filter is configured.
+	var filterUserNames map[string]bool
+	filterByUserNames := func(userNames []string) {
+		filtered := map[string]bool{}
+		for _, userName := range userNames {
+			if filterUserNames == nil || filterUserNames[userName] {
+				filtered[userName] = true
+			}
+		}
+		filterUserNames = filtered
+	}
+
+	if !(plan.GroupName.IsNull() || plan.GroupName.IsUnknown()) {
+		userNames, err := listRamGroupUsers(d.client, plan.GroupName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Read Users for Group",
+				err.Error(),
+			)
+			return
+		}
+		filterByUserNames(userNames)
+	}
+
+	if !(plan.PolicyName.IsNull() || plan.PolicyName.IsUnknown()) {
+		userNames, _, err := listRamPolicyEntities(d.client, plan.PolicyName.ValueString(), plan.PolicyType.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Read Entities for Policy",
+				err.Error(),
+			)
+			return
+		}
+		filterByUserNames(userNames)
+	}
+
+	state := &ramUsersDataSourceModel{
+		NameRegex:  plan.NameRegex,
+		GroupName:  plan.GroupName,
+		PolicyName: plan.PolicyName,
+		PolicyType: plan.PolicyType,
+		Users:      []*ramUser{},
+	}
+
+	listUsers := func() error {
+		runtime := &util.RuntimeOptions{}
+		state.Users = []*ramUser{}
+
+		listUsersRequest := &alicloudRamClient.ListUsersRequest{
+			MaxItems: tea.Int32(1000),
+		}
+
+		for {
+			listUsersResponse, err := d.client.ListUsersWithOptions(listUsersRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+
+			if listUsersResponse.Body.Users != nil {
+				for _, user := range listUsersResponse.Body.Users.User {
+					userName := tea.StringValue(user.UserName)
+					if nameRegex != nil && !nameRegex.MatchString(userName) {
+						continue
+					}
+					if filterUserNames != nil && !filterUserNames[userName] {
+						continue
+					}
+					state.Users = append(state.Users, &ramUser{
+						ID:          types.StringValue(tea.StringValue(user.UserId)),
+						Name:        types.StringValue(userName),
+						DisplayName: types.StringValue(tea.StringValue(user.DisplayName)),
+						Comments:    types.StringValue(tea.StringValue(user.Comments)),
+						CreateDate:  types.StringValue(tea.StringValue(user.CreateDate)),
+						UpdateDate:  types.StringValue(tea.StringValue(user.UpdateDate)),
+					})
+				}
+			}
+
+			if !tea.BoolValue(listUsersResponse.Body.IsTruncated) {
+				break
+			}
+			listUsersRequest.Marker = listUsersResponse.Body.Marker
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(listUsers, reconnectBackoff)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to List Users",
+			err.Error(),
+		)
+		return
+	}
+
+	names := []string{}
+	for _, user := range state.Users {
+		names = append(names, user.Name.ValueString())
+	}
+	namesList, diags := types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Names = namesList
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// listRamPolicyEntities returns the names of the users and groups that the
+// policy is attached to.
+func listRamPolicyEntities(client *alicloudRamClient.Client, policyName, policyType string) (userNames, groupNames []string, err error) {
+	listEntitiesForPolicy := func() error {
+		runtime := &util.RuntimeOptions{}
+		userNames = []string{}
+		groupNames = []string{}
+
+		listEntitiesForPolicyRequest := &alicloudRamClient.ListEntitiesForPolicyRequest{
+			PolicyName: tea.String(policyName),
+			PolicyType: tea.String(policyType),
+		}
+
+		listEntitiesForPolicyResponse, err := client.ListEntitiesForPolicyWithOptions(listEntitiesForPolicyRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+
+		if listEntitiesForPolicyResponse.Body.Users != nil {
+			for _, user := range listEntitiesForPolicyResponse.Body.Users.User {
+				userNames = append(userNames, tea.StringValue(user.UserName))
+			}
+		}
+		if listEntitiesForPolicyResponse.Body.Groups != nil {
+			for _, group := range listEntitiesForPolicyResponse.Body.Groups.Group {
+				groupNames = append(groupNames, tea.StringValue(group.GroupName))
+			}
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(listEntitiesForPolicy, reconnectBackoff)
+	return
+}
@@ -12,6 +12,20 @@ const (
 	ERR_UNKNOWN_ERROR         = "UnknownError"
 	ERR_INTERNAL_ERROR        = "InternalError"
 	ERR_BACKEND_TIMEOUT       = "D504TO"
+	ERR_NOT_APPLICABLE        = "NotApplicable"
+
+	ERR_INVALID_RR_NO_EXIST              = "InvalidRR.NoExist"
+	ERR_DOMAIN_RECORD_NOT_BELONG_TO_USER = "DomainRecordNotBelongToUser"
+	ERR_INVALID_DOMAIN_NAME_NO_EXIST     = "InvalidDomainName.NoExist"
+	ERR_INCORRECT_DOMAIN_USER            = "IncorrectDomainUser"
+	ERR_INVALID_DNS_PRODUCT              = "InvalidDnsProduct"
+
+	ERR_ENTITY_NOT_EXIST_GROUP         = "EntityNotExist.Group"
+	ERR_ENTITY_NOT_EXIST_USER          = "EntityNotExist.User"
+	ERR_ENTITY_NOT_EXIST_ACCESS_KEY    = "EntityNotExist.User.AccessKey"
+	ERR_ENTITY_NOT_EXIST_ROLE          = "EntityNotExist.Role"
+	ERR_ENTITY_NOT_EXIST_LOGIN_PROFILE = "EntityNotExist.User.LoginProfile"
+	ERR_ENTITY_NOT_EXIST_MFA_DEVICE    = "EntityNotExist.User.MFADevice"
 )
 
 func isAbleToRetry(errCode string) bool {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"strings"
 
+	alicloudBaseClient "github.com/alibabacloud-go/bssopenapi-20171214/v3/client"
 	alicloudOpenapiClient "github.com/alibabacloud-go/darabonba-openapi/v2/client"
 	"github.com/alibabacloud-go/tea/tea"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -93,3 +94,63 @@ func initNewClient(providerConfig *alicloudOpenapiClient.Client, planConfig *cli
 
 	return
 }
+
+// diffStringSlices returns the elements that are only in the old slice and
+// the elements that are only in the new slice.
+func diffStringSlices(old, new []string) (removed, added []string) {
+	oldSet := make(map[string]bool, len(old))
+	for _, s := range old {
+		oldSet[s] = true
+	}
+	newSet := make(map[string]bool, len(new))
+	for _, s := range new {
+		newSet[s] = true
+		if !oldSet[s] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range old {
+		if !newSet[s] {
+			removed = append(removed, s)
+		}
+	}
+	return
+}
+
+// bssChinaEndpoint and bssInternationalEndpoint are the BSS endpoints of the
+// China site and the international site accounts.
+const (
+	bssChinaEndpoint         = "business.aliyuncs.com"
+	bssInternationalEndpoint = "business.ap-southeast-1.aliyuncs.com"
+)
+
+// newBssClient creates a BSS client on the endpoint with the credentials of
+// the provider client, so that the endpoint of the provider client, which is
+// shared by all the resources, is never changed.
+func newBssClient(providerClient *alicloudBaseClient.Client, endpoint string) (*alicloudBaseClient.Client, error) {
+	accessKeyId, err := providerClient.Credential.GetAccessKeyId()
+	if err != nil {
+		return nil, err
+	}
+	accessKeySecret, err := providerClient.Credential.GetAccessKeySecret()
+	if err != nil {
+		return nil, err
+	}
+
+	return alicloudBaseClient.NewClient(&alicloudOpenapiClient.Config{
+		RegionId:        providerClient.RegionId,
+		AccessKeyId:     accessKeyId,
+		AccessKeySecret: accessKeySecret,
+		Endpoint:        tea.String(endpoint),
+	})
+}
+
+// getBssRenewPeriod returns the automatic renewal period of the instance in
+// months.
+func getBssRenewPeriod(availableInstance *alicloudBaseClient.QueryAvailableInstancesResponseBodyDataInstanceList) int64 {
+	renewalDuration := int64(tea.Int32Value(availableInstance.RenewalDuration))
+	if tea.StringValue(availableInstance.RenewalDurationUnit) == "Y" {
+		renewalDuration *= 12
+	}
+	return renewalDuration
+}
@@ -347,17 +347,43 @@ func (p *alicloudProvider) DataSources(_ context.Context) []func() datasource.Da
 		NewDdosCooInstancesDataSource,
 		NewDdosCooDomainResourcesDataSource,
 		NewSlbLoadBalancersDataSource,
+		NewRamPolicyDocumentDataSource,
+		NewRamPolicySimulationDataSource,
+		NewRamUsersDataSource,
+		NewRamGroupsDataSource,
+		NewRamPoliciesDataSource,
+		NewRamUserEffectivePoliciesDataSource,
+		NewAlidnsRecordsDataSource,
+		NewAlidnsZoneFileDataSource,
+		NewAlidnsGtmInstancesDataSource,
+		NewAlidnsInstancesDataSource,
 	}
 }
 
 func (p *alicloudProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewAliDnsRecordWeightResource,
+		NewAlidnsDomainResource,
 		NewAliDnsGtmInstanceResource,
+		NewAlidnsGtmAddressPoolResource,
+		NewAlidnsGtmAccessStrategyResource,
+		NewAlidnsGtmMonitorResource,
+		NewAlidnsRecordResource,
+		NewAlidnsWeightedRecordSetResource,
+		NewAlidnsZoneFileResource,
+		NewAlidnsRecordBatchResource,
 		NewRamUserGroupAttachmentResource,
+		NewRamUserResource,
+		NewRamGroupMembershipResource,
+		NewRamAccessKeyResource,
+		NewRamRoleResource,
+		NewRamAccountSecurityResource,
 		NewRamPolicyResource,
 		NewCmsAlarmRuleResource,
 		NewAlidnsDomainAttachmentResource,
+		NewAlidnsInstanceDomainsResource,
+		NewAlidnsCustomLineResource,
+		NewBssInstanceRenewalResource,
 		NewAlidnsInstanceResource,
 		NewCmsSystemEventContactGroupAttachmentResource,
 		NewDdosCooWebconfigSslAttachmentResource,
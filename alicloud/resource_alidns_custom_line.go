@@ -0,0 +1,572 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                   = &alidnsCustomLineResource{}
+	_ resource.ResourceWithConfigure      = &alidnsCustomLineResource{}
+	_ resource.ResourceWithImportState    = &alidnsCustomLineResource{}
+	_ resource.ResourceWithModifyPlan     = &alidnsCustomLineResource{}
+	_ resource.ResourceWithValidateConfig = &alidnsCustomLineResource{}
+)
+
+func NewAlidnsCustomLineResource() resource.Resource {
+	return &alidnsCustomLineResource{}
+}
+
+type alidnsCustomLineResource struct {
+	client *alicloudDnsClient.Client
+}
+
+type alidnsCustomLineResourceModel struct {
+	DomainName       types.String `tfsdk:"domain_name"`
+	LineName         types.String `tfsdk:"line_name"`
+	IpSegments       types.Set    `tfsdk:"ip_segments"`
+	MergedIpSegments types.List   `tfsdk:"merged_ip_segments"`
+	LineId           types.String `tfsdk:"line_id"`
+	LineCode         types.String `tfsdk:"line_code"`
+}
+
+// alidnsIpSegment is an inclusive range of IP addresses of the same family.
+type alidnsIpSegment struct {
+	startIp netip.Addr
+	endIp   netip.Addr
+}
+
+func (s alidnsIpSegment) String() string {
+	return s.startIp.String() + "-" + s.endIp.String()
+}
+
+func (r *alidnsCustomLineResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_custom_line"
+}
+
+func (r *alidnsCustomLineResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a custom line of a domain, which resolves the DNS queries from the IP segments " +
+			"of the line. The code of the line can be used as the line of the records and the access " +
+			"strategies of Global Traffic Manager.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description: "The domain name of the custom line.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"line_name": schema.StringAttribute{
+				Description: "The name of the custom line.",
+				Required:    true,
+			},
+			"ip_segments": schema.SetAttribute{
+				Description: "The IP segments of the custom line, in CIDR notation (e.g. 192.0.2.0/24), as a range " +
+					"(e.g. 192.0.2.1-192.0.2.100) or as a single IP address. Overlapping segments are merged.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"merged_ip_segments": schema.ListAttribute{
+				Description: "The IP segments of the custom line after merging, as ranges.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"line_id": schema.StringAttribute{
+				Description: "The ID of the custom line.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"line_code": schema.StringAttribute{
+				Description: "The code of the custom line, which is used as the line of the records.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *alidnsCustomLineResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+func (r *alidnsCustomLineResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var ipSegments types.Set
+	getAttributeDiags := req.Config.GetAttribute(ctx, path.Root("ip_segments"), &ipSegments)
+	resp.Diagnostics.Append(getAttributeDiags...)
+	if resp.Diagnostics.HasError() || ipSegments.IsNull() || ipSegments.IsUnknown() {
+		return
+	}
+
+	for _, element := range ipSegments.Elements() {
+		ipSegment, ok := element.(types.String)
+		if !ok || ipSegment.IsUnknown() {
+			continue
+		}
+		if _, err := parseAlidnsIpSegment(ipSegment.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ip_segments"),
+				"[Input Error] Invalid IP Segment",
+				err.Error(),
+			)
+		}
+	}
+}
+
+func (r *alidnsCustomLineResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan *alidnsCustomLineResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The merged segments are known in the plan when the segments are known,
+	// so that the plan shows the segments sent to the API.
+	mergedIpSegments, err := mergeAlidnsIpSegmentsOf(plan.IpSegments)
+	if err != nil {
+		return
+	}
+	plan.MergedIpSegments = mergedIpSegments
+
+	setPlanDiags := resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(setPlanDiags...)
+}
+
+func (r *alidnsCustomLineResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *alidnsCustomLineResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ipSegments, err := mergeAlidnsIpSegmentsOf(plan.IpSegments)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ip_segments"),
+			"[Input Error] Invalid IP Segment",
+			err.Error(),
+		)
+		return
+	}
+
+	addCustomLineRequest := &alicloudDnsClient.AddCustomLineRequest{
+		DomainName: tea.String(plan.DomainName.ValueString()),
+		LineName:   tea.String(plan.LineName.ValueString()),
+	}
+	for _, ipSegment := range ipSegments.Elements() {
+		startIp, endIp, _ := strings.Cut(ipSegment.(types.String).ValueString(), "-")
+		addCustomLineRequest.IpSegment = append(addCustomLineRequest.IpSegment, &alicloudDnsClient.AddCustomLineRequestIpSegment{
+			StartIp: tea.String(startIp),
+			EndIp:   tea.String(endIp),
+		})
+	}
+
+	var addCustomLineResponse *alicloudDnsClient.AddCustomLineResponse
+	addCustomLine := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		addCustomLineResponse, err = r.client.AddCustomLineWithOptions(addCustomLineRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(addCustomLine, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Add Custom Line",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &alidnsCustomLineResourceModel{
+		DomainName:       plan.DomainName,
+		LineName:         plan.LineName,
+		IpSegments:       plan.IpSegments,
+		MergedIpSegments: ipSegments,
+		LineId:           types.StringValue(strconv.FormatInt(tea.Int64Value(addCustomLineResponse.Body.LineId), 10)),
+		LineCode:         types.StringValue(tea.StringValue(addCustomLineResponse.Body.LineCode)),
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsCustomLineResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *alidnsCustomLineResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lineId, err := strconv.ParseInt(state.LineId.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[Input Error] Invalid Custom Line ID",
+			fmt.Sprintf("The ID of the custom line must be a number, got %q.", state.LineId.ValueString()),
+		)
+		return
+	}
+
+	var customLine *alicloudDnsClient.DescribeCustomLineResponseBody
+	describeCustomLine := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		describeCustomLineRequest := &alicloudDnsClient.DescribeCustomLineRequest{
+			LineId: tea.Int64(lineId),
+		}
+
+		describeCustomLineResponse, err := r.client.DescribeCustomLineWithOptions(describeCustomLineRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		customLine = describeCustomLineResponse.Body
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(describeCustomLine, reconnectBackoff); err != nil {
+		// The error code of a deleted custom line is not documented, so the
+		// custom line is looked up in the custom lines of the domain instead.
+		if !state.DomainName.IsNull() {
+			exists, listErr := r.isCustomLineExist(state.DomainName.ValueString(), lineId)
+			if listErr == nil && !exists {
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Custom Line",
+			err.Error(),
+		)
+		return
+	}
+
+	state.DomainName = types.StringValue(tea.StringValue(customLine.DomainName))
+	state.LineName = types.StringValue(tea.StringValue(customLine.Name))
+	state.LineCode = types.StringValue(tea.StringValue(customLine.Code))
+
+	ipSegments := []attr.Value{}
+	for _, ipSegment := range customLine.IpSegmentList {
+		ipSegments = append(ipSegments, types.StringValue(tea.StringValue(ipSegment.StartIp)+"-"+tea.StringValue(ipSegment.EndIp)))
+	}
+	mergedIpSegments, err := mergeAlidnsIpSegmentsOf(types.SetValueMust(types.StringType, ipSegments))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[ERROR] Failed to Parse IP Segments of Custom Line",
+			err.Error(),
+		)
+		return
+	}
+
+	// The configured segments are kept when they merge to the same segments
+	// as the custom line, otherwise the segments of the custom line are shown
+	// as the drift.
+	configuredIpSegments, err := mergeAlidnsIpSegmentsOf(state.IpSegments)
+	if err != nil || !configuredIpSegments.Equal(mergedIpSegments) {
+		state.IpSegments = types.SetValueMust(types.StringType, mergedIpSegments.Elements())
+	}
+	state.MergedIpSegments = mergedIpSegments
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsCustomLineResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *alidnsCustomLineResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ipSegments, err := mergeAlidnsIpSegmentsOf(plan.IpSegments)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ip_segments"),
+			"[Input Error] Invalid IP Segment",
+			err.Error(),
+		)
+		return
+	}
+
+	lineId, err := strconv.ParseInt(state.LineId.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[Input Error] Invalid Custom Line ID",
+			fmt.Sprintf("The ID of the custom line must be a number, got %q.", state.LineId.ValueString()),
+		)
+		return
+	}
+
+	updateCustomLineRequest := &alicloudDnsClient.UpdateCustomLineRequest{
+		LineId:   tea.Int64(lineId),
+		LineName: tea.String(plan.LineName.ValueString()),
+	}
+	for _, ipSegment := range ipSegments.Elements() {
+		startIp, endIp, _ := strings.Cut(ipSegment.(types.String).ValueString(), "-")
+		updateCustomLineRequest.IpSegment = append(updateCustomLineRequest.IpSegment, &alicloudDnsClient.UpdateCustomLineRequestIpSegment{
+			StartIp: tea.String(startIp),
+			EndIp:   tea.String(endIp),
+		})
+	}
+
+	updateCustomLine := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		if _, err := r.client.UpdateCustomLineWithOptions(updateCustomLineRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(updateCustomLine, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update Custom Line",
+			err.Error(),
+		)
+		return
+	}
+
+	state.LineName = plan.LineName
+	state.IpSegments = plan.IpSegments
+	state.MergedIpSegments = ipSegments
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsCustomLineResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *alidnsCustomLineResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteCustomLines := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		deleteCustomLinesRequest := &alicloudDnsClient.DeleteCustomLinesRequest{
+			LineIds: tea.String(state.LineId.ValueString()),
+		}
+
+		if _, err := r.client.DeleteCustomLinesWithOptions(deleteCustomLinesRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(deleteCustomLines, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Delete Custom Line",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *alidnsCustomLineResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("line_id"), req, resp)
+}
+
+// isCustomLineExist checks whether the custom line is in the custom lines of
+// the domain, paging through DescribeCustomLines.
+func (r *alidnsCustomLineResource) isCustomLineExist(domainName string, lineId int64) (exists bool, err error) {
+	describeCustomLines := func() error {
+		runtime := &util.RuntimeOptions{}
+		exists = false
+
+		describeCustomLinesRequest := &alicloudDnsClient.DescribeCustomLinesRequest{
+			DomainName: tea.String(domainName),
+			PageNumber: tea.Int64(1),
+			PageSize:   tea.Int64(100),
+		}
+
+		count := 0
+		for {
+			describeCustomLinesResponse, err := r.client.DescribeCustomLinesWithOptions(describeCustomLinesRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+
+			customLines := describeCustomLinesResponse.Body.CustomLines
+			if len(customLines) == 0 {
+				break
+			}
+			for _, customLine := range customLines {
+				if tea.Int64Value(customLine.Id) == lineId {
+					exists = true
+					return nil
+				}
+			}
+
+			count += len(customLines)
+			if count >= int(tea.Int32Value(describeCustomLinesResponse.Body.TotalItems)) {
+				break
+			}
+			describeCustomLinesRequest.PageNumber = tea.Int64(tea.Int64Value(describeCustomLinesRequest.PageNumber) + 1)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(describeCustomLines, reconnectBackoff)
+	return
+}
+
+// parseAlidnsIpSegment parses an IP segment in CIDR notation, as a range of
+// two IP addresses joined by a hyphen, or as a single IP address.
+func parseAlidnsIpSegment(ipSegment string) (alidnsIpSegment, error) {
+	ipSegment = strings.TrimSpace(ipSegment)
+
+	if strings.Contains(ipSegment, "/") {
+		prefix, err := netip.ParsePrefix(ipSegment)
+		if err != nil {
+			return alidnsIpSegment{}, fmt.Errorf("invalid CIDR %q: %s", ipSegment, err.Error())
+		}
+		prefix = prefix.Masked()
+		// The last address of the prefix has all the host bits set.
+		endIp := prefix.Addr().AsSlice()
+		for bit := prefix.Bits(); bit < len(endIp)*8; bit++ {
+			endIp[bit/8] |= 0x80 >> (bit % 8)
+		}
+		endAddr, _ := netip.AddrFromSlice(endIp)
+		return alidnsIpSegment{startIp: prefix.Addr(), endIp: endAddr}, nil
+	}
+
+	if startIp, endIp, ok := strings.Cut(ipSegment, "-"); ok {
+		startAddr, err := netip.ParseAddr(strings.TrimSpace(startIp))
+		if err != nil {
+			return alidnsIpSegment{}, fmt.Errorf("invalid start IP of the range %q: %s", ipSegment, err.Error())
+		}
+		endAddr, err := netip.ParseAddr(strings.TrimSpace(endIp))
+		if err != nil {
+			return alidnsIpSegment{}, fmt.Errorf("invalid end IP of the range %q: %s", ipSegment, err.Error())
+		}
+		if startAddr.Is4() != endAddr.Is4() {
+			return alidnsIpSegment{}, fmt.Errorf("the start and end IP of the range %q must be of the same family", ipSegment)
+		}
+		if endAddr.Less(startAddr) {
+			return alidnsIpSegment{}, fmt.Errorf("the start IP of the range %q must not be greater than the end IP", ipSegment)
+		}
+		return alidnsIpSegment{startIp: startAddr, endIp: endAddr}, nil
+	}
+
+	addr, err := netip.ParseAddr(ipSegment)
+	if err != nil {
+		return alidnsIpSegment{}, fmt.Errorf("invalid IP segment %q, expected a CIDR, a range or an IP address", ipSegment)
+	}
+	return alidnsIpSegment{startIp: addr, endIp: addr}, nil
+}
+
+// mergeAlidnsIpSegments sorts the IP segments and merges the overlapping and
+// adjacent segments of the same family.
+func mergeAlidnsIpSegments(ipSegments []alidnsIpSegment) []alidnsIpSegment {
+	sort.Slice(ipSegments, func(i, j int) bool {
+		return ipSegments[i].startIp.Less(ipSegments[j].startIp)
+	})
+
+	merged := []alidnsIpSegment{}
+	for _, ipSegment := range ipSegments {
+		if len(merged) > 0 {
+			last := &merged[len(merged)-1]
+			if last.endIp.Is4() == ipSegment.startIp.Is4() &&
+				(!last.endIp.Less(ipSegment.startIp) || last.endIp.Next() == ipSegment.startIp) {
+				if last.endIp.Less(ipSegment.endIp) {
+					last.endIp = ipSegment.endIp
+				}
+				continue
+			}
+		}
+		merged = append(merged, ipSegment)
+	}
+	return merged
+}
+
+// mergeAlidnsIpSegmentsOf merges a set of IP segments to a list of ranges. An
+// unknown list is returned when the set is not known.
+func mergeAlidnsIpSegmentsOf(ipSegmentsSet types.Set) (types.List, error) {
+	if ipSegmentsSet.IsNull() || ipSegmentsSet.IsUnknown() {
+		return types.ListUnknown(types.StringType), nil
+	}
+
+	ipSegments := []alidnsIpSegment{}
+	for _, element := range ipSegmentsSet.Elements() {
+		ipSegment, ok := element.(types.String)
+		if !ok || ipSegment.IsUnknown() {
+			return types.ListUnknown(types.StringType), nil
+		}
+		parsed, err := parseAlidnsIpSegment(ipSegment.ValueString())
+		if err != nil {
+			return types.ListNull(types.StringType), err
+		}
+		ipSegments = append(ipSegments, parsed)
+	}
+
+	merged := []attr.Value{}
+	for _, ipSegment := range mergeAlidnsIpSegments(ipSegments) {
+		merged = append(merged, types.StringValue(ipSegment.String()))
+	}
+	return types.ListValueMust(types.StringType, merged), nil
+}
@@ -0,0 +1,613 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                = &alidnsDomainResource{}
+	_ resource.ResourceWithConfigure   = &alidnsDomainResource{}
+	_ resource.ResourceWithImportState = &alidnsDomainResource{}
+	_ resource.ResourceWithModifyPlan  = &alidnsDomainResource{}
+)
+
+func NewAlidnsDomainResource() resource.Resource {
+	return &alidnsDomainResource{}
+}
+
+type alidnsDomainResource struct {
+	client *alicloudDnsClient.Client
+}
+
+type alidnsDomainResourceModel struct {
+	DomainName   types.String `tfsdk:"domain_name"`
+	GroupId      types.String `tfsdk:"group_id"`
+	Remark       types.String `tfsdk:"remark"`
+	DnssecStatus types.String `tfsdk:"dnssec_status"`
+	ForceDestroy types.Bool   `tfsdk:"force_destroy"`
+	DomainId     types.String `tfsdk:"domain_id"`
+	DnsServers   types.List   `tfsdk:"dns_servers"`
+	DsRecord     types.String `tfsdk:"ds_record"`
+	DsKeyTag     types.String `tfsdk:"ds_key_tag"`
+	DsAlgorithm  types.String `tfsdk:"ds_algorithm"`
+	DsDigestType types.String `tfsdk:"ds_digest_type"`
+	DsDigest     types.String `tfsdk:"ds_digest"`
+}
+
+func (r *alidnsDomainResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_domain"
+}
+
+func (r *alidnsDomainResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides an Alidns domain resource with its domain group, remark and DNSSEC status. " +
+			"The domain can be bound to an Alidns instance with the Alidns domain attachment resource.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description: "The domain name.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				Description: "The ID of the domain group. The domain is in the default group when it is not set.",
+				Optional:    true,
+			},
+			"remark": schema.StringAttribute{
+				Description: "The remark of the domain.",
+				Optional:    true,
+			},
+			"dnssec_status": schema.StringAttribute{
+				Description: "The DNSSEC status of the domain. Valid values: ON, OFF. Default to OFF. " +
+					"DNSSEC is only available for the domains bound to an enterprise edition instance.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ON", "OFF"),
+				},
+				Default: stringdefault.StaticString("OFF"),
+			},
+			"force_destroy": schema.BoolAttribute{
+				Description: "Whether to delete the domain when it still has records. Default to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"domain_id": schema.StringAttribute{
+				Description: "The ID of the domain.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dns_servers": schema.ListAttribute{
+				Description: "The DNS servers assigned to the domain, which are set as the name servers at the registrar.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ds_record": schema.StringAttribute{
+				Description: "The DS record to add at the registrar when DNSSEC is on.",
+				Computed:    true,
+			},
+			"ds_key_tag": schema.StringAttribute{
+				Description: "The key tag of the DS record.",
+				Computed:    true,
+			},
+			"ds_algorithm": schema.StringAttribute{
+				Description: "The algorithm of the DS record.",
+				Computed:    true,
+			},
+			"ds_digest_type": schema.StringAttribute{
+				Description: "The digest type of the DS record.",
+				Computed:    true,
+			},
+			"ds_digest": schema.StringAttribute{
+				Description: "The digest of the DS record.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *alidnsDomainResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+// ModifyPlan keeps the DS record info from the state when the DNSSEC status
+// is not changed, since the DS record only changes with the DNSSEC status.
+func (r *alidnsDomainResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state *alidnsDomainResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.DnssecStatus.Equal(state.DnssecStatus) {
+		return
+	}
+
+	plan.DsRecord = state.DsRecord
+	plan.DsKeyTag = state.DsKeyTag
+	plan.DsAlgorithm = state.DsAlgorithm
+	plan.DsDigestType = state.DsDigestType
+	plan.DsDigest = state.DsDigest
+
+	setPlanDiags := resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(setPlanDiags...)
+}
+
+func (r *alidnsDomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *alidnsDomainResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	addDomain := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		addDomainRequest := &alicloudDnsClient.AddDomainRequest{
+			DomainName: tea.String(plan.DomainName.ValueString()),
+		}
+		if !plan.GroupId.IsNull() {
+			addDomainRequest.GroupId = tea.String(plan.GroupId.ValueString())
+		}
+
+		if _, err := r.client.AddDomainWithOptions(addDomainRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(addDomain, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Add Domain",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &alidnsDomainResourceModel{
+		DomainName:   plan.DomainName,
+		GroupId:      plan.GroupId,
+		Remark:       types.StringNull(),
+		DnssecStatus: types.StringValue("OFF"),
+		ForceDestroy: plan.ForceDestroy,
+	}
+	if err := r.readDomain(state); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Domain",
+			err.Error(),
+		)
+		return
+	}
+
+	// Save the domain to the state first, so that it is not orphaned when
+	// the remark or the DNSSEC status fails to be set.
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Remark.IsNull() {
+		if err := r.updateRemark(plan.DomainName.ValueString(), plan.Remark.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Update Domain Remark",
+				err.Error(),
+			)
+			return
+		}
+		state.Remark = plan.Remark
+	}
+
+	if plan.DnssecStatus.ValueString() == "ON" {
+		if err := r.setDnssecStatus(plan.DomainName.ValueString(), "ON"); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Set Domain DNSSEC Status",
+				err.Error(),
+			)
+			return
+		}
+		state.DnssecStatus = plan.DnssecStatus
+	}
+
+	if err := r.readDnssecInfo(state); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Domain DNSSEC Info",
+			err.Error(),
+		)
+		return
+	}
+
+	setStateDiags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsDomainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *alidnsDomainResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readDomain(state); err != nil {
+		if isAlidnsDomainNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Domain",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := r.readDnssecInfo(state); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Domain DNSSEC Info",
+			err.Error(),
+		)
+		return
+	}
+
+	// The force destroy flag is not returned by the API, and is null after
+	// the domain is imported.
+	if state.ForceDestroy.IsNull() {
+		state.ForceDestroy = types.BoolValue(false)
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsDomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *alidnsDomainResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := plan.DomainName.ValueString()
+
+	if !plan.GroupId.Equal(state.GroupId) {
+		if err := r.changeGroup(domainName, plan.GroupId.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Change Domain Group",
+				err.Error(),
+			)
+			return
+		}
+		state.GroupId = plan.GroupId
+	}
+
+	if !plan.Remark.Equal(state.Remark) {
+		if err := r.updateRemark(domainName, plan.Remark.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Update Domain Remark",
+				err.Error(),
+			)
+			return
+		}
+		state.Remark = plan.Remark
+	}
+
+	if !plan.DnssecStatus.Equal(state.DnssecStatus) {
+		if err := r.setDnssecStatus(domainName, plan.DnssecStatus.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Set Domain DNSSEC Status",
+				err.Error(),
+			)
+			return
+		}
+		state.DnssecStatus = plan.DnssecStatus
+	}
+
+	if err := r.readDnssecInfo(state); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Domain DNSSEC Info",
+			err.Error(),
+		)
+		return
+	}
+	state.ForceDestroy = plan.ForceDestroy
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsDomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *alidnsDomainResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := state.DomainName.ValueString()
+
+	if !state.ForceDestroy.ValueBool() {
+		recordCount, err := r.countRecords(domainName)
+		if err != nil {
+			if isAlidnsDomainNotFound(err) {
+				return
+			}
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Describe Domain Records",
+				err.Error(),
+			)
+			return
+		}
+		if recordCount > 0 {
+			resp.Diagnostics.AddError(
+				"[ERROR] Domain Still Has Records",
+				fmt.Sprintf("The domain %s still has %d records. Delete the records first, or set force_destroy "+
+					"to true to delete the domain together with its records.", domainName, recordCount),
+			)
+			return
+		}
+	}
+
+	deleteDomain := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		deleteDomainRequest := &alicloudDnsClient.DeleteDomainRequest{
+			DomainName: tea.String(domainName),
+		}
+
+		if _, err := r.client.DeleteDomainWithOptions(deleteDomainRequest, runtime); err != nil {
+			if isAlidnsDomainNotFound(err) {
+				return nil
+			}
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(deleteDomain, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Delete Domain",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *alidnsDomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("domain_name"), req, resp)
+}
+
+// readDomain sets the domain info to the state. The group ID and the remark
+// are left null when they are empty in both the API and the state.
+func (r *alidnsDomainResource) readDomain(state *alidnsDomainResourceModel) error {
+	var domainInfo *alicloudDnsClient.DescribeDomainInfoResponseBody
+	describeDomainInfo := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		describeDomainInfoRequest := &alicloudDnsClient.DescribeDomainInfoRequest{
+			DomainName: tea.String(state.DomainName.ValueString()),
+		}
+
+		describeDomainInfoResponse, err := r.client.DescribeDomainInfoWithOptions(describeDomainInfoRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		domainInfo = describeDomainInfoResponse.Body
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(describeDomainInfo, reconnectBackoff); err != nil {
+		return err
+	}
+
+	state.DomainId = types.StringValue(tea.StringValue(domainInfo.DomainId))
+
+	dnsServers := []attr.Value{}
+	if domainInfo.DnsServers != nil {
+		for _, dnsServer := range domainInfo.DnsServers.DnsServer {
+			dnsServers = append(dnsServers, types.StringValue(tea.StringValue(dnsServer)))
+		}
+	}
+	state.DnsServers = types.ListValueMust(types.StringType, dnsServers)
+
+	if groupId := tea.StringValue(domainInfo.GroupId); groupId != "" || !state.GroupId.IsNull() {
+		state.GroupId = types.StringValue(groupId)
+	}
+	if remark := tea.StringValue(domainInfo.Remark); remark != "" || !state.Remark.IsNull() {
+		state.Remark = types.StringValue(remark)
+	}
+	return nil
+}
+
+// readDnssecInfo sets the DNSSEC status and the DS record info to the state.
+func (r *alidnsDomainResource) readDnssecInfo(state *alidnsDomainResourceModel) error {
+	var dnssecInfo *alicloudDnsClient.DescribeDomainDnssecInfoResponseBody
+	describeDomainDnssecInfo := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		describeDomainDnssecInfoRequest := &alicloudDnsClient.DescribeDomainDnssecInfoRequest{
+			DomainName: tea.String(state.DomainName.ValueString()),
+		}
+
+		describeDomainDnssecInfoResponse, err := r.client.DescribeDomainDnssecInfoWithOptions(describeDomainDnssecInfoRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		dnssecInfo = describeDomainDnssecInfoResponse.Body
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(describeDomainDnssecInfo, reconnectBackoff); err != nil {
+		return err
+	}
+
+	if tea.StringValue(dnssecInfo.Status) == "ON" {
+		state.DnssecStatus = types.StringValue("ON")
+	} else {
+		state.DnssecStatus = types.StringValue("OFF")
+	}
+	state.DsRecord = types.StringValue(tea.StringValue(dnssecInfo.DsRecord))
+	state.DsKeyTag = types.StringValue(tea.StringValue(dnssecInfo.KeyTag))
+	state.DsAlgorithm = types.StringValue(tea.StringValue(dnssecInfo.Algorithm))
+	state.DsDigestType = types.StringValue(tea.StringValue(dnssecInfo.DigestType))
+	state.DsDigest = types.StringValue(tea.StringValue(dnssecInfo.Digest))
+	return nil
+}
+
+// changeGroup moves the domain to the group, an empty group ID moves the
+// domain to the default group.
+func (r *alidnsDomainResource) changeGroup(domainName, groupId string) error {
+	changeDomainGroup := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		changeDomainGroupRequest := &alicloudDnsClient.ChangeDomainGroupRequest{
+			DomainName: tea.String(domainName),
+		}
+		if groupId != "" {
+			changeDomainGroupRequest.GroupId = tea.String(groupId)
+		}
+
+		if _, err := r.client.ChangeDomainGroupWithOptions(changeDomainGroupRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(changeDomainGroup, reconnectBackoff)
+}
+
+func (r *alidnsDomainResource) updateRemark(domainName, remark string) error {
+	updateDomainRemark := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		updateDomainRemarkRequest := &alicloudDnsClient.UpdateDomainRemarkRequest{
+			DomainName: tea.String(domainName),
+			Remark:     tea.String(remark),
+		}
+
+		if _, err := r.client.UpdateDomainRemarkWithOptions(updateDomainRemarkRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(updateDomainRemark, reconnectBackoff)
+}
+
+func (r *alidnsDomainResource) setDnssecStatus(domainName, status string) error {
+	setDomainDnssecStatus := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		setDomainDnssecStatusRequest := &alicloudDnsClient.SetDomainDnssecStatusRequest{
+			DomainName: tea.String(domainName),
+			Status:     tea.String(status),
+		}
+
+		if _, err := r.client.SetDomainDnssecStatusWithOptions(setDomainDnssecStatusRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(setDomainDnssecStatus, reconnectBackoff)
+}
+
+// countRecords returns the number of the records of the domain.
+func (r *alidnsDomainResource) countRecords(domainName string) (recordCount int64, err error) {
+	describeDomainRecords := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		describeDomainRecordsRequest := &alicloudDnsClient.DescribeDomainRecordsRequest{
+			DomainName: tea.String(domainName),
+			PageNumber: tea.Int64(1),
+			PageSize:   tea.Int64(1),
+		}
+
+		describeDomainRecordsResponse, err := r.client.DescribeDomainRecordsWithOptions(describeDomainRecordsRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		recordCount = tea.Int64Value(describeDomainRecordsResponse.Body.TotalCount)
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(describeDomainRecords, reconnectBackoff)
+	return
+}
+
+func isAlidnsDomainNotFound(err error) bool {
+	if _t, ok := err.(*tea.SDKError); ok {
+		switch tea.StringValue(_t.Code) {
+		case ERR_INVALID_DOMAIN_NAME_NO_EXIST, ERR_INCORRECT_DOMAIN_USER:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,899 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                   = &alidnsGtmAccessStrategyResource{}
+	_ resource.ResourceWithConfigure      = &alidnsGtmAccessStrategyResource{}
+	_ resource.ResourceWithImportState    = &alidnsGtmAccessStrategyResource{}
+	_ resource.ResourceWithValidateConfig = &alidnsGtmAccessStrategyResource{}
+	_ resource.ResourceWithModifyPlan     = &alidnsGtmAccessStrategyResource{}
+)
+
+func NewAlidnsGtmAccessStrategyResource() resource.Resource {
+	return &alidnsGtmAccessStrategyResource{}
+}
+
+type alidnsGtmAccessStrategyResource struct {
+	client *alicloudDnsClient.Client
+}
+
+type alidnsGtmAccessStrategyResourceModel struct {
+	InstanceId                  types.String                    `tfsdk:"instance_id"`
+	StrategyName                types.String                    `tfsdk:"strategy_name"`
+	StrategyMode                types.String                    `tfsdk:"strategy_mode"`
+	Lines                       types.Set                       `tfsdk:"lines"`
+	DefaultAddrPoolSet          *alidnsGtmAccessStrategyPoolSet `tfsdk:"default_addr_pool_set"`
+	FailoverAddrPoolSet         *alidnsGtmAccessStrategyPoolSet `tfsdk:"failover_addr_pool_set"`
+	AccessMode                  types.String                    `tfsdk:"access_mode"`
+	StrategyId                  types.String                    `tfsdk:"strategy_id"`
+	EffectiveAddrPoolGroupType  types.String                    `tfsdk:"effective_addr_pool_group_type"`
+	DefaultAddrPoolGroupStatus  types.String                    `tfsdk:"default_addr_pool_group_status"`
+	FailoverAddrPoolGroupStatus types.String                    `tfsdk:"failover_addr_pool_group_status"`
+}
+
+type alidnsGtmAccessStrategyPoolSet struct {
+	AddrPoolType        types.String                   `tfsdk:"addr_pool_type"`
+	AddrPools           []*alidnsGtmAccessStrategyPool `tfsdk:"addr_pools"`
+	LbaStrategy         types.String                   `tfsdk:"lba_strategy"`
+	MaxReturnAddrNum    types.Int64                    `tfsdk:"max_return_addr_num"`
+	MinAvailableAddrNum types.Int64                    `tfsdk:"min_available_addr_num"`
+	LatencyOptimization types.String                   `tfsdk:"latency_optimization"`
+}
+
+type alidnsGtmAccessStrategyPool struct {
+	AddrPoolId types.String `tfsdk:"addr_pool_id"`
+	LbaWeight  types.Int64  `tfsdk:"lba_weight"`
+}
+
+// alidnsGtmAccessStrategyPoolSetResult is a pool set as returned by the API,
+// the default and the failover pool sets are returned in different types.
+type alidnsGtmAccessStrategyPoolSetResult struct {
+	addrPoolType        string
+	addrPools           map[string]int32
+	lbaStrategy         string
+	maxReturnAddrNum    int32
+	minAvailableAddrNum int32
+	latencyOptimization string
+}
+
+func (r *alidnsGtmAccessStrategyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_gtm_access_strategy"
+}
+
+func alidnsGtmAccessStrategyPoolSetSchema(description string, required bool) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: description,
+		Required:    required,
+		Optional:    !required,
+		Attributes: map[string]schema.Attribute{
+			"addr_pool_type": schema.StringAttribute{
+				Description: "The type of the address pools. Valid values: IPV4, IPV6, DOMAIN.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("IPV4", "IPV6", "DOMAIN"),
+				},
+			},
+			"addr_pools": schema.SetNestedAttribute{
+				Description: "The address pools in the pool set.",
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"addr_pool_id": schema.StringAttribute{
+							Description: "The ID of the address pool.",
+							Required:    true,
+						},
+						"lba_weight": schema.Int64Attribute{
+							Description: "The weight of the address pool. Valid values: 1 to 100. Required when the lba strategy is RATIO.",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.Between(1, 100),
+							},
+						},
+					},
+				},
+			},
+			"lba_strategy": schema.StringAttribute{
+				Description: "The load balancing strategy of the address pools. Valid values: ALL_RR, RATIO.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ALL_RR", "RATIO"),
+				},
+			},
+			"max_return_addr_num": schema.Int64Attribute{
+				Description: "The maximum number of the addresses returned, for the LATENCY strategy mode.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"min_available_addr_num": schema.Int64Attribute{
+				Description: "The minimum number of the available addresses. The pool set is unavailable when " +
+					"fewer addresses are available.",
+				Required: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"latency_optimization": schema.StringAttribute{
+				Description: "Whether to return the address pools with the lowest latency, for the LATENCY strategy mode. " +
+					"Valid values: OPEN, CLOSE.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("OPEN", "CLOSE"),
+				},
+			},
+		},
+	}
+}
+
+func (r *alidnsGtmAccessStrategyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// The API keeps the failover pool set when it is not sent, so the access
+	// strategy is replaced when the failover pool set is removed.
+	failoverAddrPoolSetSchema := alidnsGtmAccessStrategyPoolSetSchema(
+		"The failover address pool set, which is returned when the default address pool set is unavailable. "+
+			"The access strategy is replaced when it is removed.", false)
+	failoverAddrPoolSetSchema.PlanModifiers = []planmodifier.Object{
+		objectplanmodifier.RequiresReplaceIf(
+			func(_ context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+				resp.RequiresReplace = !req.StateValue.IsNull() && req.PlanValue.IsNull()
+			},
+			"The access strategy is replaced when the failover pool set is removed.",
+			"The access strategy is replaced when the failover pool set is removed.",
+		),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Provides an access strategy of a Global Traffic Manager instance, which returns the " +
+			"default address pool set and switches to the failover address pool set when the default one is unavailable.",
+		Attributes: map[string]schema.Attribute{
+			"instance_id": schema.StringAttribute{
+				Description: "The ID of the Global Traffic Manager instance.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"strategy_name": schema.StringAttribute{
+				Description: "The name of the access strategy.",
+				Required:    true,
+			},
+			"strategy_mode": schema.StringAttribute{
+				Description: "The mode of the access strategy, which should match the strategy mode of the instance. " +
+					"Valid values: GEO, LATENCY.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("GEO", "LATENCY"),
+				},
+			},
+			"lines": schema.SetAttribute{
+				Description: "The line codes of the access strategy, e.g. default. For the LATENCY strategy mode, " +
+					"these are the line codes of the source regions to measure the latency from.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"default_addr_pool_set": alidnsGtmAccessStrategyPoolSetSchema(
+				"The default address pool set, which is returned while it is available.", true),
+			"failover_addr_pool_set": failoverAddrPoolSetSchema,
+			"access_mode": schema.StringAttribute{
+				Description: "The policy to switch the effective address pool set. Valid values: AUTO (switch to the " +
+					"failover pool set automatically), DEFAULT (always the default pool set), FAILOVER (always the " +
+					"failover pool set). Default to AUTO.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("AUTO", "DEFAULT", "FAILOVER"),
+				},
+				Default: stringdefault.StaticString("AUTO"),
+			},
+			"strategy_id": schema.StringAttribute{
+				Description: "The ID of the access strategy.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"effective_addr_pool_group_type": schema.StringAttribute{
+				Description: "The address pool set in effect, DEFAULT or FAILOVER. A change of this value " +
+					"outside of Terraform means that the access strategy has failed over.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"default_addr_pool_group_status": schema.StringAttribute{
+				Description: "The availability of the default address pool set.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"failover_addr_pool_group_status": schema.StringAttribute{
+				Description: "The availability of the failover address pool set.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *alidnsGtmAccessStrategyResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+func (r *alidnsGtmAccessStrategyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// The pool sets can only be validated when they are known.
+	for _, name := range []string{"default_addr_pool_set", "failover_addr_pool_set"} {
+		var poolSet types.Object
+		getAttributeDiags := req.Config.GetAttribute(ctx, path.Root(name), &poolSet)
+		resp.Diagnostics.Append(getAttributeDiags...)
+		if resp.Diagnostics.HasError() || poolSet.IsUnknown() {
+			return
+		}
+		if addrPools, ok := poolSet.Attributes()["addr_pools"]; ok && addrPools.IsUnknown() {
+			return
+		}
+	}
+
+	var config *alidnsGtmAccessStrategyResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	poolSets := map[string]*alidnsGtmAccessStrategyPoolSet{
+		"default_addr_pool_set":  config.DefaultAddrPoolSet,
+		"failover_addr_pool_set": config.FailoverAddrPoolSet,
+	}
+	for name, poolSet := range poolSets {
+		if poolSet == nil {
+			continue
+		}
+		if poolSet.LbaStrategy.ValueString() == "RATIO" {
+			for _, pool := range poolSet.AddrPools {
+				if pool.LbaWeight.IsNull() {
+					resp.Diagnostics.AddAttributeError(
+						path.Root(name).AtName("addr_pools"),
+						"[Input Error] Missing Address Pool Weight",
+						fmt.Sprintf("The weight of the address pool %s must be configured when the lba strategy is RATIO.", pool.AddrPoolId.ValueString()),
+					)
+				}
+			}
+		}
+		if config.StrategyMode.ValueString() == "GEO" && (!poolSet.MaxReturnAddrNum.IsNull() || !poolSet.LatencyOptimization.IsNull()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"[Input Error] Invalid Pool Set",
+				"The max_return_addr_num and latency_optimization are only valid for the LATENCY strategy mode.",
+			)
+		}
+	}
+
+	if config.FailoverAddrPoolSet == nil && (config.AccessMode.ValueString() == "FAILOVER") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("access_mode"),
+			"[Input Error] Missing Failover Pool Set",
+			"The failover_addr_pool_set must be configured when the access mode is FAILOVER.",
+		)
+	}
+}
+
+// ModifyPlan plans the status of the pool sets as unknown when the access mode
+// or the pool sets are changed, as they are read again after the update.
+func (r *alidnsGtmAccessStrategyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Skip on create and destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planAccessMode, stateAccessMode types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("access_mode"), &planAccessMode)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("access_mode"), &stateAccessMode)...)
+	changed := !planAccessMode.Equal(stateAccessMode)
+	for _, name := range []string{"default_addr_pool_set", "failover_addr_pool_set"} {
+		var planPoolSet, statePoolSet types.Object
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(name), &planPoolSet)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(name), &statePoolSet)...)
+		changed = changed || !planPoolSet.Equal(statePoolSet)
+	}
+	if resp.Diagnostics.HasError() || !changed {
+		return
+	}
+
+	for _, name := range []string{"effective_addr_pool_group_type", "default_addr_pool_group_status", "failover_addr_pool_group_status"} {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), types.StringUnknown())...)
+	}
+}
+
+func (r *alidnsGtmAccessStrategyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *alidnsGtmAccessStrategyResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	addDnsGtmAccessStrategyRequest, err := r.buildAddRequest(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[ERROR] Failed to Build Access Strategy Request",
+			err.Error(),
+		)
+		return
+	}
+
+	var strategyId string
+	addDnsGtmAccessStrategy := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		addDnsGtmAccessStrategyResponse, err := r.client.AddDnsGtmAccessStrategyWithOptions(addDnsGtmAccessStrategyRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		strategyId = tea.StringValue(addDnsGtmAccessStrategyResponse.Body.StrategyId)
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(addDnsGtmAccessStrategy, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Add GTM Access Strategy",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &alidnsGtmAccessStrategyResourceModel{
+		InstanceId:                  plan.InstanceId,
+		StrategyName:                plan.StrategyName,
+		StrategyMode:                plan.StrategyMode,
+		Lines:                       plan.Lines,
+		DefaultAddrPoolSet:          plan.DefaultAddrPoolSet,
+		FailoverAddrPoolSet:         plan.FailoverAddrPoolSet,
+		AccessMode:                  types.StringValue("AUTO"),
+		StrategyId:                  types.StringValue(strategyId),
+		EffectiveAddrPoolGroupType:  types.StringNull(),
+		DefaultAddrPoolGroupStatus:  types.StringNull(),
+		FailoverAddrPoolGroupStatus: types.StringNull(),
+	}
+
+	// Save the access strategy to the state first, so that it is not
+	// orphaned when the access mode fails to be set.
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.AccessMode.ValueString() != "AUTO" {
+		if err := r.setAccessMode(strategyId, plan.AccessMode.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Set GTM Access Mode",
+				err.Error(),
+			)
+			return
+		}
+		state.AccessMode = plan.AccessMode
+	}
+
+	if err := r.readStatus(state); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe GTM Access Strategy",
+			err.Error(),
+		)
+		return
+	}
+
+	setStateDiags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsGtmAccessStrategyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *alidnsGtmAccessStrategyResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	strategy, err := r.describeAccessStrategy(state.StrategyId.ValueString())
+	if err != nil {
+		// The error code of a deleted access strategy is not documented, so
+		// the access strategy is looked up in the strategies of the instance.
+		if !state.InstanceId.IsNull() {
+			exists, listErr := r.isAccessStrategyExist(state.InstanceId.ValueString(), state.StrategyMode.ValueString(), state.StrategyId.ValueString())
+			if listErr == nil && !exists {
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe GTM Access Strategy",
+			err.Error(),
+		)
+		return
+	}
+
+	state.InstanceId = types.StringValue(tea.StringValue(strategy.InstanceId))
+	state.StrategyName = types.StringValue(tea.StringValue(strategy.StrategyName))
+	state.StrategyMode = types.StringValue(tea.StringValue(strategy.StrategyMode))
+	state.AccessMode = types.StringValue(tea.StringValue(strategy.AccessMode))
+	state.EffectiveAddrPoolGroupType = types.StringValue(tea.StringValue(strategy.EffectiveAddrPoolGroupType))
+	state.DefaultAddrPoolGroupStatus = types.StringValue(tea.StringValue(strategy.DefaultAddrPoolGroupStatus))
+	state.FailoverAddrPoolGroupStatus = types.StringValue(tea.StringValue(strategy.FailoverAddrPoolGroupStatus))
+
+	lines := []attr.Value{}
+	if strategy.Lines != nil {
+		for _, line := range strategy.Lines.Line {
+			lines = append(lines, types.StringValue(tea.StringValue(line.LineCode)))
+		}
+	}
+	state.Lines = types.SetValueMust(types.StringType, lines)
+
+	defaultPoolSet := &alidnsGtmAccessStrategyPoolSetResult{
+		addrPoolType:        tea.StringValue(strategy.DefaultAddrPoolType),
+		addrPools:           map[string]int32{},
+		lbaStrategy:         tea.StringValue(strategy.DefaultLbaStrategy),
+		maxReturnAddrNum:    tea.Int32Value(strategy.DefaultMaxReturnAddrNum),
+		minAvailableAddrNum: tea.Int32Value(strategy.DefaultMinAvailableAddrNum),
+		latencyOptimization: tea.StringValue(strategy.DefaultLatencyOptimization),
+	}
+	if strategy.DefaultAddrPools != nil {
+		for _, pool := range strategy.DefaultAddrPools.DefaultAddrPool {
+			defaultPoolSet.addrPools[tea.StringValue(pool.Id)] = tea.Int32Value(pool.LbaWeight)
+		}
+	}
+	state.DefaultAddrPoolSet = defaultPoolSet.toModel(state.DefaultAddrPoolSet)
+
+	failoverPoolSet := &alidnsGtmAccessStrategyPoolSetResult{
+		addrPoolType:        tea.StringValue(strategy.FailoverAddrPoolType),
+		addrPools:           map[string]int32{},
+		lbaStrategy:         tea.StringValue(strategy.FailoverLbaStrategy),
+		maxReturnAddrNum:    tea.Int32Value(strategy.FailoverMaxReturnAddrNum),
+		minAvailableAddrNum: tea.Int32Value(strategy.FailoverMinAvailableAddrNum),
+		latencyOptimization: tea.StringValue(strategy.FailoverLatencyOptimization),
+	}
+	if strategy.FailoverAddrPools != nil {
+		for _, pool := range strategy.FailoverAddrPools.FailoverAddrPool {
+			failoverPoolSet.addrPools[tea.StringValue(pool.Id)] = tea.Int32Value(pool.LbaWeight)
+		}
+	}
+	state.FailoverAddrPoolSet = failoverPoolSet.toModel(state.FailoverAddrPoolSet)
+
+	// The failover is only reflected in the computed attributes, which are
+	// not shown in the plan, so it is surfaced as a warning.
+	if state.EffectiveAddrPoolGroupType.ValueString() == "FAILOVER" && state.AccessMode.ValueString() != "FAILOVER" {
+		resp.Diagnostics.AddWarning(
+			"[API WARNING] GTM Access Strategy Failed Over",
+			fmt.Sprintf("The access strategy %s (%s) has failed over to the failover pool set, the default pool set is %s.",
+				state.StrategyName.ValueString(), state.StrategyId.ValueString(), state.DefaultAddrPoolGroupStatus.ValueString()),
+		)
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsGtmAccessStrategyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *alidnsGtmAccessStrategyResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateDnsGtmAccessStrategyRequest, err := r.buildUpdateRequest(ctx, plan, state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[ERROR] Failed to Build Access Strategy Request",
+			err.Error(),
+		)
+		return
+	}
+
+	updateDnsGtmAccessStrategy := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		if _, err := r.client.UpdateDnsGtmAccessStrategyWithOptions(updateDnsGtmAccessStrategyRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(updateDnsGtmAccessStrategy, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update GTM Access Strategy",
+			err.Error(),
+		)
+		return
+	}
+
+	state.StrategyName = plan.StrategyName
+	state.Lines = plan.Lines
+	state.DefaultAddrPoolSet = plan.DefaultAddrPoolSet
+	state.FailoverAddrPoolSet = plan.FailoverAddrPoolSet
+
+	if !plan.AccessMode.Equal(state.AccessMode) {
+		if err := r.setAccessMode(state.StrategyId.ValueString(), plan.AccessMode.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Set GTM Access Mode",
+				err.Error(),
+			)
+			return
+		}
+		state.AccessMode = plan.AccessMode
+	}
+
+	if err := r.readStatus(state); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe GTM Access Strategy",
+			err.Error(),
+		)
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsGtmAccessStrategyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *alidnsGtmAccessStrategyResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteDnsGtmAccessStrategy := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		deleteDnsGtmAccessStrategyRequest := &alicloudDnsClient.DeleteDnsGtmAccessStrategyRequest{
+			StrategyId: tea.String(state.StrategyId.ValueString()),
+		}
+
+		if _, err := r.client.DeleteDnsGtmAccessStrategyWithOptions(deleteDnsGtmAccessStrategyRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(deleteDnsGtmAccessStrategy, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Delete GTM Access Strategy",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *alidnsGtmAccessStrategyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("strategy_id"), req, resp)
+}
+
+func (r *alidnsGtmAccessStrategyResource) buildAddRequest(ctx context.Context, plan *alidnsGtmAccessStrategyResourceModel) (*alicloudDnsClient.AddDnsGtmAccessStrategyRequest, error) {
+	lines := []string{}
+	if diags := plan.Lines.ElementsAs(ctx, &lines, false); diags.HasError() {
+		return nil, fmt.Errorf("failed to read the lines of the access strategy")
+	}
+
+	addDnsGtmAccessStrategyRequest := &alicloudDnsClient.AddDnsGtmAccessStrategyRequest{
+		InstanceId:   tea.String(plan.InstanceId.ValueString()),
+		StrategyName: tea.String(plan.StrategyName.ValueString()),
+		StrategyMode: tea.String(plan.StrategyMode.ValueString()),
+		Lines:        tea.String(convertListStringToJsonString(lines)),
+	}
+
+	defaultPoolSet := plan.DefaultAddrPoolSet
+	addDnsGtmAccessStrategyRequest.DefaultAddrPoolType = tea.String(defaultPoolSet.AddrPoolType.ValueString())
+	addDnsGtmAccessStrategyRequest.DefaultMinAvailableAddrNum = tea.Int32(int32(defaultPoolSet.MinAvailableAddrNum.ValueInt64()))
+	if !defaultPoolSet.LbaStrategy.IsNull() {
+		addDnsGtmAccessStrategyRequest.DefaultLbaStrategy = tea.String(defaultPoolSet.LbaStrategy.ValueString())
+	}
+	if !defaultPoolSet.MaxReturnAddrNum.IsNull() {
+		addDnsGtmAccessStrategyRequest.DefaultMaxReturnAddrNum = tea.Int32(int32(defaultPoolSet.MaxReturnAddrNum.ValueInt64()))
+	}
+	if !defaultPoolSet.LatencyOptimization.IsNull() {
+		addDnsGtmAccessStrategyRequest.DefaultLatencyOptimization = tea.String(defaultPoolSet.LatencyOptimization.ValueString())
+	}
+	for _, pool := range defaultPoolSet.AddrPools {
+		addrPool := &alicloudDnsClient.AddDnsGtmAccessStrategyRequestDefaultAddrPool{
+			Id: tea.String(pool.AddrPoolId.ValueString()),
+		}
+		if !pool.LbaWeight.IsNull() {
+			addrPool.LbaWeight = tea.Int32(int32(pool.LbaWeight.ValueInt64()))
+		}
+		addDnsGtmAccessStrategyRequest.DefaultAddrPool = append(addDnsGtmAccessStrategyRequest.DefaultAddrPool, addrPool)
+	}
+
+	if failoverPoolSet := plan.FailoverAddrPoolSet; failoverPoolSet != nil {
+		addDnsGtmAccessStrategyRequest.FailoverAddrPoolType = tea.String(failoverPoolSet.AddrPoolType.ValueString())
+		addDnsGtmAccessStrategyRequest.FailoverMinAvailableAddrNum = tea.Int32(int32(failoverPoolSet.MinAvailableAddrNum.ValueInt64()))
+		if !failoverPoolSet.LbaStrategy.IsNull() {
+			addDnsGtmAccessStrategyRequest.FailoverLbaStrategy = tea.String(failoverPoolSet.LbaStrategy.ValueString())
+		}
+		if !failoverPoolSet.MaxReturnAddrNum.IsNull() {
+			addDnsGtmAccessStrategyRequest.FailoverMaxReturnAddrNum = tea.Int32(int32(failoverPoolSet.MaxReturnAddrNum.ValueInt64()))
+		}
+		if !failoverPoolSet.LatencyOptimization.IsNull() {
+			addDnsGtmAccessStrategyRequest.FailoverLatencyOptimization = tea.String(failoverPoolSet.LatencyOptimization.ValueString())
+		}
+		for _, pool := range failoverPoolSet.AddrPools {
+			addrPool := &alicloudDnsClient.AddDnsGtmAccessStrategyRequestFailoverAddrPool{
+				Id: tea.String(pool.AddrPoolId.ValueString()),
+			}
+			if !pool.LbaWeight.IsNull() {
+				addrPool.LbaWeight = tea.Int32(int32(pool.LbaWeight.ValueInt64()))
+			}
+			addDnsGtmAccessStrategyRequest.FailoverAddrPool = append(addDnsGtmAccessStrategyRequest.FailoverAddrPool, addrPool)
+		}
+	}
+	return addDnsGtmAccessStrategyRequest, nil
+}
+
+func (r *alidnsGtmAccessStrategyResource) buildUpdateRequest(ctx context.Context, plan, state *alidnsGtmAccessStrategyResourceModel) (*alicloudDnsClient.UpdateDnsGtmAccessStrategyRequest, error) {
+	lines := []string{}
+	if diags := plan.Lines.ElementsAs(ctx, &lines, false); diags.HasError() {
+		return nil, fmt.Errorf("failed to read the lines of the access strategy")
+	}
+
+	updateDnsGtmAccessStrategyRequest := &alicloudDnsClient.UpdateDnsGtmAccessStrategyRequest{
+		StrategyId:   tea.String(state.StrategyId.ValueString()),
+		StrategyName: tea.String(plan.StrategyName.ValueString()),
+		Lines:        tea.String(convertListStringToJsonString(lines)),
+	}
+
+	defaultPoolSet := plan.DefaultAddrPoolSet
+	updateDnsGtmAccessStrategyRequest.DefaultAddrPoolType = tea.String(defaultPoolSet.AddrPoolType.ValueString())
+	updateDnsGtmAccessStrategyRequest.DefaultMinAvailableAddrNum = tea.Int32(int32(defaultPoolSet.MinAvailableAddrNum.ValueInt64()))
+	if !defaultPoolSet.LbaStrategy.IsNull() {
+		updateDnsGtmAccessStrategyRequest.DefaultLbaStrategy = tea.String(defaultPoolSet.LbaStrategy.ValueString())
+	}
+	if !defaultPoolSet.MaxReturnAddrNum.IsNull() {
+		updateDnsGtmAccessStrategyRequest.DefaultMaxReturnAddrNum = tea.Int32(int32(defaultPoolSet.MaxReturnAddrNum.ValueInt64()))
+	}
+	if !defaultPoolSet.LatencyOptimization.IsNull() {
+		updateDnsGtmAccessStrategyRequest.DefaultLatencyOptimization = tea.String(defaultPoolSet.LatencyOptimization.ValueString())
+	}
+	for _, pool := range defaultPoolSet.AddrPools {
+		addrPool := &alicloudDnsClient.UpdateDnsGtmAccessStrategyRequestDefaultAddrPool{
+			Id: tea.String(pool.AddrPoolId.ValueString()),
+		}
+		if !pool.LbaWeight.IsNull() {
+			addrPool.LbaWeight = tea.Int32(int32(pool.LbaWeight.ValueInt64()))
+		}
+		updateDnsGtmAccessStrategyRequest.DefaultAddrPool = append(updateDnsGtmAccessStrategyRequest.DefaultAddrPool, addrPool)
+	}
+
+	// The access strategy is replaced when the failover pool set is removed.
+	failoverPoolSet := plan.FailoverAddrPoolSet
+	if failoverPoolSet == nil {
+		return updateDnsGtmAccessStrategyRequest, nil
+	}
+
+	updateDnsGtmAccessStrategyRequest.FailoverAddrPoolType = tea.String(failoverPoolSet.AddrPoolType.ValueString())
+	updateDnsGtmAccessStrategyRequest.FailoverMinAvailableAddrNum = tea.Int32(int32(failoverPoolSet.MinAvailableAddrNum.ValueInt64()))
+	if !failoverPoolSet.LbaStrategy.IsNull() {
+		updateDnsGtmAccessStrategyRequest.FailoverLbaStrategy = tea.String(failoverPoolSet.LbaStrategy.ValueString())
+	}
+	if !failoverPoolSet.MaxReturnAddrNum.IsNull() {
+		updateDnsGtmAccessStrategyRequest.FailoverMaxReturnAddrNum = tea.Int32(int32(failoverPoolSet.MaxReturnAddrNum.ValueInt64()))
+	}
+	if !failoverPoolSet.LatencyOptimization.IsNull() {
+		updateDnsGtmAccessStrategyRequest.FailoverLatencyOptimization = tea.String(failoverPoolSet.LatencyOptimization.ValueString())
+	}
+	for _, pool := range failoverPoolSet.AddrPools {
+		addrPool := &alicloudDnsClient.UpdateDnsGtmAccessStrategyRequestFailoverAddrPool{
+			Id: tea.String(pool.AddrPoolId.ValueString()),
+		}
+		if !pool.LbaWeight.IsNull() {
+			addrPool.LbaWeight = tea.Int32(int32(pool.LbaWeight.ValueInt64()))
+		}
+		updateDnsGtmAccessStrategyRequest.FailoverAddrPool = append(updateDnsGtmAccessStrategyRequest.FailoverAddrPool, addrPool)
+	}
+	return updateDnsGtmAccessStrategyRequest, nil
+}
+
+// toModel converts the pool set returned by the API to the model. The
+// optional attributes are kept null when they are null in the state, and the
+// pool set is null when it has no address pools.
+func (p *alidnsGtmAccessStrategyPoolSetResult) toModel(old *alidnsGtmAccessStrategyPoolSet) *alidnsGtmAccessStrategyPoolSet {
+	if len(p.addrPools) == 0 {
+		return nil
+	}
+
+	poolSet := &alidnsGtmAccessStrategyPoolSet{
+		AddrPoolType:        types.StringValue(p.addrPoolType),
+		MinAvailableAddrNum: types.Int64Value(int64(p.minAvailableAddrNum)),
+		LbaStrategy:         types.StringNull(),
+		MaxReturnAddrNum:    types.Int64Null(),
+		LatencyOptimization: types.StringNull(),
+	}
+	if p.lbaStrategy != "" && (old == nil || !old.LbaStrategy.IsNull()) {
+		poolSet.LbaStrategy = types.StringValue(p.lbaStrategy)
+	}
+	if p.maxReturnAddrNum != 0 && (old == nil || !old.MaxReturnAddrNum.IsNull()) {
+		poolSet.MaxReturnAddrNum = types.Int64Value(int64(p.maxReturnAddrNum))
+	}
+	if p.latencyOptimization != "" && (old == nil || !old.LatencyOptimization.IsNull()) {
+		poolSet.LatencyOptimization = types.StringValue(p.latencyOptimization)
+	}
+
+	oldWeights := map[string]types.Int64{}
+	if old != nil {
+		for _, pool := range old.AddrPools {
+			oldWeights[pool.AddrPoolId.ValueString()] = pool.LbaWeight
+		}
+	}
+	for addrPoolId, lbaWeight := range p.addrPools {
+		pool := &alidnsGtmAccessStrategyPool{
+			AddrPoolId: types.StringValue(addrPoolId),
+			LbaWeight:  types.Int64Value(int64(lbaWeight)),
+		}
+		if oldWeight, ok := oldWeights[addrPoolId]; (ok && oldWeight.IsNull()) || lbaWeight == 0 {
+			pool.LbaWeight = types.Int64Null()
+		}
+		poolSet.AddrPools = append(poolSet.AddrPools, pool)
+	}
+	return poolSet
+}
+
+// readStatus sets the access mode and the status of the pool sets, which
+// change when the access strategy fails over.
+func (r *alidnsGtmAccessStrategyResource) readStatus(state *alidnsGtmAccessStrategyResourceModel) error {
+	strategy, err := r.describeAccessStrategy(state.StrategyId.ValueString())
+	if err != nil {
+		return err
+	}
+
+	state.AccessMode = types.StringValue(tea.StringValue(strategy.AccessMode))
+	state.EffectiveAddrPoolGroupType = types.StringValue(tea.StringValue(strategy.EffectiveAddrPoolGroupType))
+	state.DefaultAddrPoolGroupStatus = types.StringValue(tea.StringValue(strategy.DefaultAddrPoolGroupStatus))
+	state.FailoverAddrPoolGroupStatus = types.StringValue(tea.StringValue(strategy.FailoverAddrPoolGroupStatus))
+	return nil
+}
+
+func (r *alidnsGtmAccessStrategyResource) describeAccessStrategy(strategyId string) (strategy *alicloudDnsClient.DescribeDnsGtmAccessStrategyResponseBody, err error) {
+	describeDnsGtmAccessStrategy := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		describeDnsGtmAccessStrategyRequest := &alicloudDnsClient.DescribeDnsGtmAccessStrategyRequest{
+			StrategyId: tea.String(strategyId),
+		}
+
+		describeDnsGtmAccessStrategyResponse, err := r.client.DescribeDnsGtmAccessStrategyWithOptions(describeDnsGtmAccessStrategyRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		strategy = describeDnsGtmAccessStrategyResponse.Body
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(describeDnsGtmAccessStrategy, reconnectBackoff)
+	return
+}
+
+// isAccessStrategyExist checks whether the access strategy is in the
+// strategies of the instance, paging through DescribeDnsGtmAccessStrategies.
+func (r *alidnsGtmAccessStrategyResource) isAccessStrategyExist(instanceId, strategyMode, strategyId string) (exists bool, err error) {
+	describeDnsGtmAccessStrategies := func() error {
+		runtime := &util.RuntimeOptions{}
+		exists = false
+
+		describeDnsGtmAccessStrategiesRequest := &alicloudDnsClient.DescribeDnsGtmAccessStrategiesRequest{
+			InstanceId:   tea.String(instanceId),
+			StrategyMode: tea.String(strategyMode),
+			PageNumber:   tea.Int32(1),
+			PageSize:     tea.Int32(100),
+		}
+
+		count := 0
+		for {
+			describeDnsGtmAccessStrategiesResponse, err := r.client.DescribeDnsGtmAccessStrategiesWithOptions(describeDnsGtmAccessStrategiesRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+
+			strategies := describeDnsGtmAccessStrategiesResponse.Body.Strategies
+			if strategies == nil || len(strategies.Strategy) == 0 {
+				break
+			}
+			for _, strategy := range strategies.Strategy {
+				if tea.StringValue(strategy.StrategyId) == strategyId {
+					exists = true
+					return nil
+				}
+			}
+
+			count += len(strategies.Strategy)
+			if count >= int(tea.Int32Value(describeDnsGtmAccessStrategiesResponse.Body.TotalItems)) {
+				break
+			}
+			describeDnsGtmAccessStrategiesRequest.PageNumber = tea.Int32(tea.Int32Value(describeDnsGtmAccessStrategiesRequest.PageNumber) + 1)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(describeDnsGtmAccessStrategies, reconnectBackoff)
+	return
+}
+
+func (r *alidnsGtmAccessStrategyResource) setAccessMode(strategyId, accessMode string) error {
+	setDnsGtmAccessMode := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		setDnsGtmAccessModeRequest := &alicloudDnsClient.SetDnsGtmAccessModeRequest{
+			StrategyId: tea.String(strategyId),
+			AccessMode: tea.String(accessMode),
+		}
+
+		if _, err := r.client.SetDnsGtmAccessModeWithOptions(setDnsGtmAccessModeRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(setDnsGtmAccessMode, reconnectBackoff)
+}
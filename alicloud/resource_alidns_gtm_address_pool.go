@@ -0,0 +1,528 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                   = &alidnsGtmAddressPoolResource{}
+	_ resource.ResourceWithConfigure      = &alidnsGtmAddressPoolResource{}
+	_ resource.ResourceWithImportState    = &alidnsGtmAddressPoolResource{}
+	_ resource.ResourceWithValidateConfig = &alidnsGtmAddressPoolResource{}
+)
+
+func NewAlidnsGtmAddressPoolResource() resource.Resource {
+	return &alidnsGtmAddressPoolResource{}
+}
+
+type alidnsGtmAddressPoolResource struct {
+	client *alicloudDnsClient.Client
+}
+
+type alidnsGtmAddressPoolResourceModel struct {
+	InstanceId      types.String                   `tfsdk:"instance_id"`
+	Name            types.String                   `tfsdk:"name"`
+	Type            types.String                   `tfsdk:"type"`
+	LbaStrategy     types.String                   `tfsdk:"lba_strategy"`
+	Addresses       []*alidnsGtmAddressPoolAddress `tfsdk:"addresses"`
+	AddrPoolId      types.String                   `tfsdk:"addr_pool_id"`
+	MonitorConfigId types.String                   `tfsdk:"monitor_config_id"`
+}
+
+type alidnsGtmAddressPoolAddress struct {
+	Address   types.String `tfsdk:"address"`
+	Mode      types.String `tfsdk:"mode"`
+	LbaWeight types.Int64  `tfsdk:"lba_weight"`
+	Remark    types.String `tfsdk:"remark"`
+}
+
+func (r *alidnsGtmAddressPoolResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_gtm_address_pool"
+}
+
+func (r *alidnsGtmAddressPoolResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides an address pool of a Global Traffic Manager instance. The minimum number of " +
+			"available addresses of the pools is configured on the access strategy that uses them.",
+		Attributes: map[string]schema.Attribute{
+			"instance_id": schema.StringAttribute{
+				Description: "The ID of the Global Traffic Manager instance.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					// The instance ID is not returned by the API, so it is
+					// only set from the configuration after an import.
+					stringplanmodifier.RequiresReplaceIf(
+						func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = !req.StateValue.IsNull()
+						},
+						"The address pool is replaced when the instance ID is changed.",
+						"The address pool is replaced when the instance ID is changed.",
+					),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the address pool.",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "The type of the addresses in the pool. Valid values: IPV4, IPV6, DOMAIN.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("IPV4", "IPV6", "DOMAIN"),
+				},
+			},
+			"lba_strategy": schema.StringAttribute{
+				Description: "The load balancing strategy of the addresses. Valid values: ALL_RR (return all the " +
+					"addresses), RATIO (return the addresses by weight).",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ALL_RR", "RATIO"),
+				},
+			},
+			"addresses": schema.SetNestedAttribute{
+				Description: "The addresses in the pool.",
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"address": schema.StringAttribute{
+							Description: "The IPv4 address, IPv6 address or domain name, depending on the type of the pool.",
+							Required:    true,
+						},
+						"mode": schema.StringAttribute{
+							Description: "The mode of the address. Valid values: SMART (switch by the health check), " +
+								"ONLINE (always online), OFFLINE (always offline).",
+							Required: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("SMART", "ONLINE", "OFFLINE"),
+							},
+						},
+						"lba_weight": schema.Int64Attribute{
+							Description: "The weight of the address. Valid values: 1 to 100. Required when the lba strategy is RATIO.",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.Between(1, 100),
+							},
+						},
+						"remark": schema.StringAttribute{
+							Description: "The remark of the address.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"addr_pool_id": schema.StringAttribute{
+				Description: "The ID of the address pool.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"monitor_config_id": schema.StringAttribute{
+				Description: "The ID of the health check configuration of the address pool.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *alidnsGtmAddressPoolResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+func (r *alidnsGtmAddressPoolResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// The addresses can only be validated when the set and its elements are known.
+	var addresses types.Set
+	getAttributeDiags := req.Config.GetAttribute(ctx, path.Root("addresses"), &addresses)
+	resp.Diagnostics.Append(getAttributeDiags...)
+	if resp.Diagnostics.HasError() || addresses.IsUnknown() {
+		return
+	}
+	for _, element := range addresses.Elements() {
+		if element.IsUnknown() {
+			return
+		}
+	}
+
+	var config *alidnsGtmAddressPoolResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, address := range config.Addresses {
+		if address.Address.IsUnknown() {
+			continue
+		}
+
+		if !config.Type.IsUnknown() {
+			if err := validateAlidnsGtmAddress(config.Type.ValueString(), address.Address.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("addresses"),
+					"[Input Error] Invalid Address",
+					err.Error(),
+				)
+			}
+		}
+
+		if config.LbaStrategy.ValueString() == "RATIO" && address.LbaWeight.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("addresses"),
+				"[Input Error] Missing Address Weight",
+				fmt.Sprintf("The weight of the address %s must be configured when the lba strategy is RATIO.", address.Address.ValueString()),
+			)
+		}
+	}
+}
+
+func (r *alidnsGtmAddressPoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *alidnsGtmAddressPoolResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var addrPoolId, monitorConfigId string
+	addDnsGtmAddressPool := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		addDnsGtmAddressPoolRequest := &alicloudDnsClient.AddDnsGtmAddressPoolRequest{
+			InstanceId:  tea.String(plan.InstanceId.ValueString()),
+			Name:        tea.String(plan.Name.ValueString()),
+			Type:        tea.String(plan.Type.ValueString()),
+			LbaStrategy: tea.String(plan.LbaStrategy.ValueString()),
+		}
+		for _, address := range plan.Addresses {
+			addr := &alicloudDnsClient.AddDnsGtmAddressPoolRequestAddr{
+				Addr: tea.String(address.Address.ValueString()),
+				Mode: tea.String(address.Mode.ValueString()),
+			}
+			if !address.LbaWeight.IsNull() {
+				addr.LbaWeight = tea.Int32(int32(address.LbaWeight.ValueInt64()))
+			}
+			if !address.Remark.IsNull() {
+				addr.Remark = tea.String(address.Remark.ValueString())
+			}
+			addDnsGtmAddressPoolRequest.Addr = append(addDnsGtmAddressPoolRequest.Addr, addr)
+		}
+
+		addDnsGtmAddressPoolResponse, err := r.client.AddDnsGtmAddressPoolWithOptions(addDnsGtmAddressPoolRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		addrPoolId = tea.StringValue(addDnsGtmAddressPoolResponse.Body.AddrPoolId)
+		monitorConfigId = tea.StringValue(addDnsGtmAddressPoolResponse.Body.MonitorConfigId)
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(addDnsGtmAddressPool, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Add GTM Address Pool",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &alidnsGtmAddressPoolResourceModel{
+		InstanceId:      plan.InstanceId,
+		Name:            plan.Name,
+		Type:            plan.Type,
+		LbaStrategy:     plan.LbaStrategy,
+		Addresses:       plan.Addresses,
+		AddrPoolId:      types.StringValue(addrPoolId),
+		MonitorConfigId: types.StringValue(monitorConfigId),
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsGtmAddressPoolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *alidnsGtmAddressPoolResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	addressPool, err := describeAlidnsGtmAddressPool(r.client, state.AddrPoolId.ValueString())
+	if err != nil {
+		// The error code of a deleted address pool is not documented, so the
+		// address pool is looked up in the pools of the instance instead.
+		if !state.InstanceId.IsNull() {
+			exists, listErr := isAlidnsGtmAddressPoolExist(r.client, state.InstanceId.ValueString(), state.AddrPoolId.ValueString())
+			if listErr == nil && !exists {
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe GTM Address Pool",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Name = types.StringValue(tea.StringValue(addressPool.Name))
+	state.Type = types.StringValue(tea.StringValue(addressPool.Type))
+	state.LbaStrategy = types.StringValue(tea.StringValue(addressPool.LbaStrategy))
+	state.MonitorConfigId = types.StringValue(tea.StringValue(addressPool.MonitorConfigId))
+
+	// The optional attributes of the addresses are kept null when they are
+	// not configured, e.g. the weight of the addresses with ALL_RR.
+	configured := map[string]*alidnsGtmAddressPoolAddress{}
+	for _, address := range state.Addresses {
+		configured[address.Address.ValueString()] = address
+	}
+	addresses := []*alidnsGtmAddressPoolAddress{}
+	if addressPool.Addrs != nil {
+		for _, addr := range addressPool.Addrs.Addr {
+			address := &alidnsGtmAddressPoolAddress{
+				Address:   types.StringValue(tea.StringValue(addr.Addr)),
+				Mode:      types.StringValue(tea.StringValue(addr.Mode)),
+				LbaWeight: types.Int64Value(int64(tea.Int32Value(addr.LbaWeight))),
+				Remark:    types.StringValue(tea.StringValue(addr.Remark)),
+			}
+			old := configured[tea.StringValue(addr.Addr)]
+			if (old == nil || old.LbaWeight.IsNull()) && tea.StringValue(addressPool.LbaStrategy) != "RATIO" {
+				address.LbaWeight = types.Int64Null()
+			}
+			if (old == nil || old.Remark.IsNull()) && tea.StringValue(addr.Remark) == "" {
+				address.Remark = types.StringNull()
+			}
+			addresses = append(addresses, address)
+		}
+	}
+	state.Addresses = addresses
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsGtmAddressPoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *alidnsGtmAddressPoolResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateDnsGtmAddressPool := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		updateDnsGtmAddressPoolRequest := &alicloudDnsClient.UpdateDnsGtmAddressPoolRequest{
+			AddrPoolId:  tea.String(state.AddrPoolId.ValueString()),
+			Name:        tea.String(plan.Name.ValueString()),
+			LbaStrategy: tea.String(plan.LbaStrategy.ValueString()),
+		}
+		for _, address := range plan.Addresses {
+			addr := &alicloudDnsClient.UpdateDnsGtmAddressPoolRequestAddr{
+				Addr: tea.String(address.Address.ValueString()),
+				Mode: tea.String(address.Mode.ValueString()),
+			}
+			if !address.LbaWeight.IsNull() {
+				addr.LbaWeight = tea.Int32(int32(address.LbaWeight.ValueInt64()))
+			}
+			if !address.Remark.IsNull() {
+				addr.Remark = tea.String(address.Remark.ValueString())
+			}
+			updateDnsGtmAddressPoolRequest.Addr = append(updateDnsGtmAddressPoolRequest.Addr, addr)
+		}
+
+		if _, err := r.client.UpdateDnsGtmAddressPoolWithOptions(updateDnsGtmAddressPoolRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(updateDnsGtmAddressPool, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update GTM Address Pool",
+			err.Error(),
+		)
+		return
+	}
+
+	state.InstanceId = plan.InstanceId
+	state.Name = plan.Name
+	state.LbaStrategy = plan.LbaStrategy
+	state.Addresses = plan.Addresses
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsGtmAddressPoolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *alidnsGtmAddressPoolResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteDnsGtmAddressPool := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		deleteDnsGtmAddressPoolRequest := &alicloudDnsClient.DeleteDnsGtmAddressPoolRequest{
+			AddrPoolId: tea.String(state.AddrPoolId.ValueString()),
+		}
+
+		if _, err := r.client.DeleteDnsGtmAddressPoolWithOptions(deleteDnsGtmAddressPoolRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(deleteDnsGtmAddressPool, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Delete GTM Address Pool",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *alidnsGtmAddressPoolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("addr_pool_id"), req, resp)
+}
+
+// isAlidnsGtmAddressPoolExist checks whether the address pool is in the pools
+// of the instance, paging through DescribeDnsGtmInstanceAddressPools.
+func isAlidnsGtmAddressPoolExist(client *alicloudDnsClient.Client, instanceId, addrPoolId string) (exists bool, err error) {
+	describeDnsGtmInstanceAddressPools := func() error {
+		runtime := &util.RuntimeOptions{}
+		exists = false
+
+		describeDnsGtmInstanceAddressPoolsRequest := &alicloudDnsClient.DescribeDnsGtmInstanceAddressPoolsRequest{
+			InstanceId: tea.String(instanceId),
+			PageNumber: tea.Int32(1),
+			PageSize:   tea.Int32(100),
+		}
+
+		count := 0
+		for {
+			describeDnsGtmInstanceAddressPoolsResponse, err := client.DescribeDnsGtmInstanceAddressPoolsWithOptions(describeDnsGtmInstanceAddressPoolsRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+
+			addrPools := describeDnsGtmInstanceAddressPoolsResponse.Body.AddrPools
+			if addrPools == nil || len(addrPools.AddrPool) == 0 {
+				break
+			}
+			for _, addrPool := range addrPools.AddrPool {
+				if tea.StringValue(addrPool.AddrPoolId) == addrPoolId {
+					exists = true
+					return nil
+				}
+			}
+
+			count += len(addrPools.AddrPool)
+			if count >= int(tea.Int32Value(describeDnsGtmInstanceAddressPoolsResponse.Body.TotalItems)) {
+				break
+			}
+			describeDnsGtmInstanceAddressPoolsRequest.PageNumber = tea.Int32(tea.Int32Value(describeDnsGtmInstanceAddressPoolsRequest.PageNumber) + 1)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(describeDnsGtmInstanceAddressPools, reconnectBackoff)
+	return
+}
+
+func describeAlidnsGtmAddressPool(client *alicloudDnsClient.Client, addrPoolId string) (addressPool *alicloudDnsClient.DescribeDnsGtmInstanceAddressPoolResponseBody, err error) {
+	describeDnsGtmInstanceAddressPool := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		describeDnsGtmInstanceAddressPoolRequest := &alicloudDnsClient.DescribeDnsGtmInstanceAddressPoolRequest{
+			AddrPoolId: tea.String(addrPoolId),
+		}
+
+		describeDnsGtmInstanceAddressPoolResponse, err := client.DescribeDnsGtmInstanceAddressPoolWithOptions(describeDnsGtmInstanceAddressPoolRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		addressPool = describeDnsGtmInstanceAddressPoolResponse.Body
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(describeDnsGtmInstanceAddressPool, reconnectBackoff)
+	return
+}
+
+// validateAlidnsGtmAddress checks that the address is valid for the type of
+// the address pool.
+func validateAlidnsGtmAddress(poolType, address string) error {
+	ip := net.ParseIP(address)
+	switch poolType {
+	case "IPV4":
+		if ip == nil || ip.To4() == nil {
+			return fmt.Errorf("the addresses of IPV4 pools must be IPv4 addresses, got %q", address)
+		}
+	case "IPV6":
+		if ip == nil || ip.To4() != nil {
+			return fmt.Errorf("the addresses of IPV6 pools must be IPv6 addresses, got %q", address)
+		}
+	case "DOMAIN":
+		if !isValidAlidnsHostname(address) {
+			return fmt.Errorf("the addresses of DOMAIN pools must be domain names, got %q", address)
+		}
+	}
+	return nil
+}
@@ -3,6 +3,9 @@ package alicloud
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -14,7 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -74,17 +77,17 @@ type alidnsGtmInstanceResource struct {
 
 type alidnsGtmInstanceResourceModel struct {
 	// Required
-	InstanceType   types.String `tfsdk:"instance_type"`
-	InstanceName   types.String `tfsdk:"instance_name"`
-	PaymentType    types.String `tfsdk:"payment_type"`
-	PackageEdition types.String `tfsdk:"package_edition"`
-	// HealthcheckTaskCount types.Int64    `tfsdk:"health_check_task_count"`
-	Ttl             types.Int64    `tfsdk:"ttl"`
-	AlertGroup      types.List     `tfsdk:"alert_group"`
-	ResourceGroupID types.String   `tfsdk:"resource_group_id"`
-	AlertConfig     []*alertConfig `tfsdk:"alert_config"`
-	RenewPeriod     types.Int64    `tfsdk:"renew_period"`
-	RenewalStatus   types.String   `tfsdk:"renewal_status"`
+	InstanceType         types.String   `tfsdk:"instance_type"`
+	InstanceName         types.String   `tfsdk:"instance_name"`
+	PaymentType          types.String   `tfsdk:"payment_type"`
+	PackageEdition       types.String   `tfsdk:"package_edition"`
+	HealthcheckTaskCount types.Int64    `tfsdk:"health_check_task_count"`
+	Ttl                  types.Int64    `tfsdk:"ttl"`
+	AlertGroup           types.List     `tfsdk:"alert_group"`
+	ResourceGroupID      types.String   `tfsdk:"resource_group_id"`
+	AlertConfig          []*alertConfig `tfsdk:"alert_config"`
+	RenewPeriod          types.Int64    `tfsdk:"renew_period"`
+	RenewalStatus        types.String   `tfsdk:"renewal_status"`
 
 	// Optional
 	Id          types.String `tfsdk:"id"`
@@ -140,31 +143,28 @@ func (r *alidnsGtmInstanceResource) Schema(_ context.Context, req resource.Schem
 				},
 			},
 			"package_edition": schema.StringAttribute{
-				Description: "Paid package version. Valid values: ultimate, standard.",
-				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Description: "Paid package version. Valid values: ultimate, standard. Changing the " +
+					"package version upgrades or downgrades the instance in place.",
+				Required: true,
 				Validators: []validator.String{
 					stringvalidator.OneOf("standard", "ultimate"),
 				},
 			},
-			// "health_check_task_count": schema.Int64Attribute{
-			// 	Description: "The quota of detection tasks.",
-			// 	Required:    true,
-			// 	PlanModifiers: []planmodifier.Int64{
-			// 		int64planmodifier.RequiresReplace(),
-			// 	},
-			// 	Validators: []validator.Int64{
-			// 		int64validator.Between(0, 100000),
-			// 	},
-			// },
-			"sms_notification_count": schema.Int64Attribute{
-				Description: "The quota of SMS notifications.",
-				Optional:    true,
-				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.RequiresReplace(),
+			"health_check_task_count": schema.Int64Attribute{
+				Description: "The quota of health check tasks, which is ordered with the instance. " +
+					"Each health check monitor of an address pool uses the quota. Changing the quota " +
+					"upgrades or downgrades the instance in place. Default to 0.",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 100000),
 				},
+			},
+			"sms_notification_count": schema.Int64Attribute{
+				Description: "The quota of SMS notifications, only for the cn instance type. Changing " +
+					"the quota upgrades or downgrades the instance in place.",
+				Optional: true,
 				Validators: []validator.Int64{
 					int64validator.Between(0, 100000),
 				},
@@ -345,7 +345,7 @@ func (r *alidnsGtmInstanceResource) Create(ctx context.Context, req resource.Cre
 			},
 			{
 				Code:  tea.String("HealthcheckTaskCount"),
-				Value: tea.String(fmt.Sprint(0)),
+				Value: tea.String(fmt.Sprint(plan.HealthcheckTaskCount.ValueInt64())),
 			},
 			{
 				Code:  tea.String("SmsNotificationCount"),
@@ -362,7 +362,7 @@ func (r *alidnsGtmInstanceResource) Create(ctx context.Context, req resource.Cre
 			},
 			{
 				Code:  tea.String("HealthcheckTaskCount"),
-				Value: tea.String(fmt.Sprint(0)),
+				Value: tea.String(fmt.Sprint(plan.HealthcheckTaskCount.ValueInt64())),
 			},
 		}
 	}
@@ -422,6 +422,7 @@ func (r *alidnsGtmInstanceResource) Create(ctx context.Context, req resource.Cre
 	state.InstanceType = plan.InstanceType
 	state.PaymentType = plan.PaymentType
 	state.PackageEdition = plan.PackageEdition
+	state.HealthcheckTaskCount = plan.HealthcheckTaskCount
 	if accountType == "cn" {
 		state.SmsNotificationCount = plan.SmsNotificationCount
 	}
@@ -504,6 +505,13 @@ func (r *alidnsGtmInstanceResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
+	/*
+		The order parameters are modified before the global config, so that a
+		failed order does not block the rest of the update.
+	*/
+	modifyOrderDiags := r.modifyGtmInstanceOrder(plan, state)
+	resp.Diagnostics.Append(modifyOrderDiags...)
+
 	/*
 		Do not check resp.Diagnostics.HasError() so that Terraform will update its
 		state as the update process include three API calls.
@@ -638,6 +646,17 @@ func (r *alidnsGtmInstanceResource) ModifyPlan(ctx context.Context, req resource
 		if resp.Diagnostics.HasError() {
 			return
 		}
+
+		// Show the price difference of the order parameters of an existing instance.
+		if !req.State.Raw.IsNull() {
+			var state *alidnsGtmInstanceResourceModel
+			getStateDiags := req.State.Get(ctx, &state)
+			resp.Diagnostics.Append(getStateDiags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			resp.Diagnostics.Append(r.planGtmInstanceOrder(plan, state)...)
+		}
 	}
 }
 
@@ -740,6 +759,12 @@ func (r *alidnsGtmInstanceResource) readGtmInstance(state *alidnsGtmInstanceReso
 	state.ResourceGroupID = types.StringValue(*describeDnsGtmInstanceResponse.Body.ResourceGroupId)
 	state.PaymentType = types.StringValue(*describeDnsGtmInstanceResponse.Body.PaymentType)
 	state.PackageEdition = types.StringValue(*describeDnsGtmInstanceResponse.Body.VersionCode)
+	if describeDnsGtmInstanceResponse.Body.TaskQuota != nil {
+		state.HealthcheckTaskCount = types.Int64Value(int64(*describeDnsGtmInstanceResponse.Body.TaskQuota))
+	}
+	if accountType == "cn" && describeDnsGtmInstanceResponse.Body.SmsQuota != nil {
+		state.SmsNotificationCount = types.Int64Value(int64(*describeDnsGtmInstanceResponse.Body.SmsQuota))
+	}
 	if describeDnsGtmInstanceResponse.Body.Config.CnameType != nil {
 		state.CnameType = types.StringValue(*describeDnsGtmInstanceResponse.Body.Config.CnameType)
 	}
@@ -990,27 +1015,250 @@ func (r *alidnsGtmInstanceResource) updateGtmInstance(plan *alidnsGtmInstanceRes
 }
 
 func (r alidnsGtmInstanceResource) setInstanceRenewal(clientEndpoint string, req *alicloudBaseClient.SetRenewalRequest) error {
-	r.baseClient.Endpoint = tea.String(clientEndpoint)
+	return setBssInstanceRenewal(r.baseClient, clientEndpoint, req)
+}
 
-	setRenewal := func() error {
-		runtime := &util.RuntimeOptions{}
-		_, err := r.baseClient.SetRenewalWithOptions(req, runtime)
+// getGtmOrderParameters returns the order parameters of the instance, which
+// are upgraded or downgraded through BSS.
+func getGtmOrderParameters(model *alidnsGtmInstanceResourceModel) map[string]string {
+	parameters := map[string]string{
+		"PackageEdition":       model.PackageEdition.ValueString(),
+		"HealthcheckTaskCount": fmt.Sprint(model.HealthcheckTaskCount.ValueInt64()),
+	}
+	if model.InstanceType.ValueString() == "cn" && !model.SmsNotificationCount.IsNull() {
+		parameters["SmsNotificationCount"] = fmt.Sprint(model.SmsNotificationCount.ValueInt64())
+	}
+	return parameters
+}
+
+func getGtmOrderParameterCodes(parameters map[string]string) []string {
+	codes := []string{}
+	for code := range parameters {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func formatGtmOrderParameters(parameters map[string]string) string {
+	formatted := []string{}
+	for _, code := range getGtmOrderParameterCodes(parameters) {
+		formatted = append(formatted, fmt.Sprintf("%s=%s", code, parameters[code]))
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// isGtmOrderParameterHigher checks whether the target value of the order
+// parameter is an upgrade of the current value.
+func isGtmOrderParameterHigher(code, target, current string) bool {
+	if code == "PackageEdition" {
+		editions := map[string]int{"standard": 1, "ultimate": 2}
+		return editions[target] > editions[current]
+	}
+	targetCount, _ := strconv.Atoi(target)
+	currentCount, _ := strconv.Atoi(current)
+	return targetCount > currentCount
+}
+
+/*
+getGtmOrderChanges splits the changes of the order parameters into an upgrade
+and a downgrade order, as an order of BSS is either one of them. The upgrade
+order keeps the current value of the parameters to downgrade, and the
+downgrade order is placed after it with all the target values. Nil is
+returned for an order that is not needed.
+*/
+func getGtmOrderChanges(plan, state *alidnsGtmInstanceResourceModel) (upgrade, downgrade map[string]string) {
+	current := getGtmOrderParameters(state)
+	target := getGtmOrderParameters(plan)
+
+	upgradeTo := map[string]string{}
+	isUpgrade, isDowngrade := false, false
+	for code, targetValue := range target {
+		currentValue, ok := current[code]
+		if !ok {
+			continue
+		}
+		upgradeTo[code] = currentValue
+		if isGtmOrderParameterHigher(code, targetValue, currentValue) {
+			upgradeTo[code] = targetValue
+			isUpgrade = true
+		} else if targetValue != currentValue {
+			isDowngrade = true
+		}
+	}
+
+	if isUpgrade {
+		upgrade = upgradeTo
+	}
+	if isDowngrade {
+		downgrade = target
+	}
+	return
+}
+
+// planGtmInstanceOrder warns about the upgrade and downgrade orders of the
+// plan, with the price of the upgrade.
+func (r *alidnsGtmInstanceResource) planGtmInstanceOrder(plan, state *alidnsGtmInstanceResourceModel) diag.Diagnostics {
+	if plan.PackageEdition.IsUnknown() || plan.HealthcheckTaskCount.IsUnknown() || plan.SmsNotificationCount.IsUnknown() {
+		return nil
+	}
+
+	diags := diag.Diagnostics{}
+	upgrade, downgrade := getGtmOrderChanges(plan, state)
+	if upgrade != nil {
+		price, err := r.getGtmUpgradePrice(state, upgrade)
 		if err != nil {
-			if _t, ok := err.(*tea.SDKError); ok {
-				if isAbleToRetry(*_t.Code) {
-					return err
-				} else {
-					return backoff.Permanent(err)
-				}
-			} else {
-				return err
+			diags.AddWarning(
+				"GTM Instance Will Be Upgraded",
+				fmt.Sprintf("The instance will be upgraded to %s, but the price of the upgrade "+
+					"is not available: %s", formatGtmOrderParameters(upgrade), err.Error()),
+			)
+		} else {
+			diags.AddWarning(
+				"GTM Instance Will Be Upgraded",
+				fmt.Sprintf("The instance will be upgraded to %s, which costs %.2f %s "+
+					"(original price %.2f %s) for the rest of the subscription.",
+					formatGtmOrderParameters(upgrade),
+					tea.Float32Value(price.TradePrice), tea.StringValue(price.Currency),
+					tea.Float32Value(price.OriginalPrice), tea.StringValue(price.Currency)),
+			)
+		}
+	}
+	if downgrade != nil {
+		diags.AddWarning(
+			"GTM Instance Will Be Downgraded",
+			fmt.Sprintf("The instance will be downgraded to %s. The refund of the downgrade is "+
+				"determined by AliCloud billing when the order is placed.", formatGtmOrderParameters(downgrade)),
+		)
+	}
+	return diags
+}
+
+// modifyGtmInstanceOrder upgrades and downgrades the order parameters of the
+// instance in place.
+func (r *alidnsGtmInstanceResource) modifyGtmInstanceOrder(plan, state *alidnsGtmInstanceResourceModel) diag.Diagnostics {
+	upgrade, downgrade := getGtmOrderChanges(plan, state)
+	for _, order := range []struct {
+		modifyType string
+		parameters map[string]string
+	}{
+		{"Upgrade", upgrade},
+		{"Downgrade", downgrade},
+	} {
+		if order.parameters == nil {
+			continue
+		}
+		if err := r.modifyGtmInstance(state, order.modifyType, order.parameters); err != nil {
+			return diag.Diagnostics{
+				diag.NewErrorDiagnostic(
+					fmt.Sprintf("[API ERROR] Failed to %s GTM Instance", order.modifyType),
+					err.Error(),
+				),
 			}
 		}
+
+		if edition, ok := order.parameters["PackageEdition"]; ok {
+			state.PackageEdition = types.StringValue(edition)
+		}
+		if count, ok := order.parameters["HealthcheckTaskCount"]; ok {
+			healthcheckTaskCount, _ := strconv.ParseInt(count, 10, 64)
+			state.HealthcheckTaskCount = types.Int64Value(healthcheckTaskCount)
+		}
+		if count, ok := order.parameters["SmsNotificationCount"]; ok {
+			smsNotificationCount, _ := strconv.ParseInt(count, 10, 64)
+			state.SmsNotificationCount = types.Int64Value(smsNotificationCount)
+		}
+	}
+	return nil
+}
+
+func getGtmBssProduct(instanceType string) (endpoint, productType string) {
+	if instanceType == "cn" {
+		return "business.aliyuncs.com", "dns_gtm_public_cn"
+	}
+	return "business.ap-southeast-1.aliyuncs.com", "dns_gtm_public_intl"
+}
+
+func (r *alidnsGtmInstanceResource) modifyGtmInstance(state *alidnsGtmInstanceResourceModel, modifyType string, parameters map[string]string) error {
+	clientEndpoint, productType := getGtmBssProduct(state.InstanceType.ValueString())
+	bssClient, err := newBssClient(r.baseClient, clientEndpoint)
+	if err != nil {
+		return err
+	}
+
+	modifyInstanceRequest := &alicloudBaseClient.ModifyInstanceRequest{
+		InstanceId:       tea.String(state.Id.ValueString()),
+		ModifyType:       tea.String(modifyType),
+		ProductCode:      tea.String("dns"),
+		ProductType:      tea.String(productType),
+		SubscriptionType: tea.String(state.PaymentType.ValueString()),
+	}
+	for _, code := range getGtmOrderParameterCodes(parameters) {
+		modifyInstanceRequest.Parameter = append(modifyInstanceRequest.Parameter, &alicloudBaseClient.ModifyInstanceRequestParameter{
+			Code:  tea.String(code),
+			Value: tea.String(parameters[code]),
+		})
+	}
+
+	modifyInstance := func() error {
+		runtime := &util.RuntimeOptions{}
+		modifyInstanceResponse, err := bssClient.ModifyInstanceWithOptions(modifyInstanceRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		// Same as creating the instance, a failed order may be returned with
+		// status code 200.
+		if !tea.BoolValue(modifyInstanceResponse.Body.Success) {
+			return backoff.Permanent(fmt.Errorf("%s: %s", tea.StringValue(modifyInstanceResponse.Body.Code), tea.StringValue(modifyInstanceResponse.Body.Message)))
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(modifyInstance, reconnectBackoff)
+}
+
+func (r *alidnsGtmInstanceResource) getGtmUpgradePrice(state *alidnsGtmInstanceResourceModel, parameters map[string]string) (price *alicloudBaseClient.GetSubscriptionPriceResponseBodyData, err error) {
+	// The provider is not configured yet when its configuration is unknown.
+	if r.baseClient == nil {
+		return nil, fmt.Errorf("the provider is not configured")
+	}
+	clientEndpoint, productType := getGtmBssProduct(state.InstanceType.ValueString())
+	bssClient, err := newBssClient(r.baseClient, clientEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	getSubscriptionPriceRequest := &alicloudBaseClient.GetSubscriptionPriceRequest{
+		InstanceId:       tea.String(state.Id.ValueString()),
+		OrderType:        tea.String("Upgrade"),
+		ProductCode:      tea.String("dns"),
+		ProductType:      tea.String(productType),
+		SubscriptionType: tea.String(state.PaymentType.ValueString()),
+	}
+	for _, code := range getGtmOrderParameterCodes(parameters) {
+		getSubscriptionPriceRequest.ModuleList = append(getSubscriptionPriceRequest.ModuleList, &alicloudBaseClient.GetSubscriptionPriceRequestModuleList{
+			ModuleCode: tea.String(code),
+			Config:     tea.String(fmt.Sprintf("%s:%s", code, parameters[code])),
+		})
+	}
+
+	getSubscriptionPrice := func() error {
+		runtime := &util.RuntimeOptions{}
+		getSubscriptionPriceResponse, err := bssClient.GetSubscriptionPriceWithOptions(getSubscriptionPriceRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		if !tea.BoolValue(getSubscriptionPriceResponse.Body.Success) || getSubscriptionPriceResponse.Body.Data == nil {
+			return backoff.Permanent(fmt.Errorf("%s: %s", tea.StringValue(getSubscriptionPriceResponse.Body.Code), tea.StringValue(getSubscriptionPriceResponse.Body.Message)))
+		}
+		price = getSubscriptionPriceResponse.Body.Data
 		return nil
 	}
 
-	// Retry backoff
 	reconnectBackoff := backoff.NewExponentialBackOff()
 	reconnectBackoff.MaxElapsedTime = 30 * time.Second
-	return backoff.Retry(setRenewal, reconnectBackoff)
+	err = backoff.Retry(getSubscriptionPrice, reconnectBackoff)
+	return
 }
@@ -0,0 +1,883 @@
+package alicloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                   = &alidnsGtmMonitorResource{}
+	_ resource.ResourceWithConfigure      = &alidnsGtmMonitorResource{}
+	_ resource.ResourceWithImportState    = &alidnsGtmMonitorResource{}
+	_ resource.ResourceWithValidateConfig = &alidnsGtmMonitorResource{}
+	_ resource.ResourceWithModifyPlan     = &alidnsGtmMonitorResource{}
+)
+
+func NewAlidnsGtmMonitorResource() resource.Resource {
+	return &alidnsGtmMonitorResource{}
+}
+
+type alidnsGtmMonitorResource struct {
+	client *alicloudDnsClient.Client
+}
+
+type alidnsGtmMonitorResourceModel struct {
+	InstanceId      types.String `tfsdk:"instance_id"`
+	AddrPoolId      types.String `tfsdk:"addr_pool_id"`
+	ProtocolType    types.String `tfsdk:"protocol_type"`
+	Interval        types.Int64  `tfsdk:"interval"`
+	EvaluationCount types.Int64  `tfsdk:"evaluation_count"`
+	Timeout         types.Int64  `tfsdk:"timeout"`
+	FailureRate     types.Int64  `tfsdk:"failure_rate"`
+	Port            types.Int64  `tfsdk:"port"`
+	Host            types.String `tfsdk:"host"`
+	Path            types.String `tfsdk:"path"`
+	Code            types.Int64  `tfsdk:"code"`
+	Sni             types.Bool   `tfsdk:"sni"`
+	PacketNum       types.Int64  `tfsdk:"packet_num"`
+	PacketLossRate  types.Int64  `tfsdk:"packet_loss_rate"`
+	NodeType        types.String `tfsdk:"node_type"`
+	IspCityNodes    types.Set    `tfsdk:"isp_city_nodes"`
+	Status          types.String `tfsdk:"status"`
+	MonitorConfigId types.String `tfsdk:"monitor_config_id"`
+}
+
+type alidnsGtmMonitorIspCityNode struct {
+	IspCode  types.String `tfsdk:"isp_code"`
+	CityCode types.String `tfsdk:"city_code"`
+}
+
+var alidnsGtmMonitorIspCityNodeType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"isp_code":  types.StringType,
+		"city_code": types.StringType,
+	},
+}
+
+// alidnsGtmMonitorAvailableNode is a probe node returned by
+// DescribeDnsGtmMonitorAvailableConfig, which returns the nodes of each pool
+// type in a different type with the same fields.
+type alidnsGtmMonitorAvailableNode struct {
+	IspCode         *string `json:"IspCode,omitempty"`
+	IspName         *string `json:"IspName,omitempty"`
+	CityCode        *string `json:"CityCode,omitempty"`
+	CityName        *string `json:"CityName,omitempty"`
+	DefaultSelected *bool   `json:"DefaultSelected,omitempty"`
+}
+
+func (r *alidnsGtmMonitorResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_gtm_monitor"
+}
+
+func (r *alidnsGtmMonitorResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides the health check monitor of an address pool of a Global Traffic Manager instance. " +
+			"The monitor uses the health check task quota of the instance. The health check is closed " +
+			"when the monitor is destroyed, since the monitor of an address pool cannot be deleted.",
+		Attributes: map[string]schema.Attribute{
+			"instance_id": schema.StringAttribute{
+				Description: "The ID of the Global Traffic Manager instance.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					// The instance ID is not returned by the API, so it is
+					// only set from the configuration after an import.
+					stringplanmodifier.RequiresReplaceIf(
+						func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = !req.StateValue.IsNull()
+						},
+						"The monitor is replaced when the instance ID is changed.",
+						"The monitor is replaced when the instance ID is changed.",
+					),
+				},
+			},
+			"addr_pool_id": schema.StringAttribute{
+				Description: "The ID of the address pool to check.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"protocol_type": schema.StringAttribute{
+				Description: "The protocol of the health check. Valid values: HTTP, HTTPS, PING, TCP.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("HTTP", "HTTPS", "PING", "TCP"),
+				},
+			},
+			"interval": schema.Int64Attribute{
+				Description: "The interval of the health checks. Valid values: 15, 60, 300, 900, 1800, 3600. " +
+					"Unit: second. Default to 60.",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(60),
+				Validators: []validator.Int64{
+					int64validator.OneOf(15, 60, 300, 900, 1800, 3600),
+				},
+			},
+			"evaluation_count": schema.Int64Attribute{
+				Description: "The number of consecutive failed health checks before an address is " +
+					"considered unavailable. Valid values: 1, 2, 3, 5. Default to 1.",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(1),
+				Validators: []validator.Int64{
+					int64validator.OneOf(1, 2, 3, 5),
+				},
+			},
+			"timeout": schema.Int64Attribute{
+				Description: "The timeout of the health checks. Valid values: 2000, 3000, 5000, 10000, " +
+					"2000 is not valid for PING. Unit: millisecond. Default to 5000.",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(5000),
+				Validators: []validator.Int64{
+					int64validator.OneOf(2000, 3000, 5000, 10000),
+				},
+			},
+			"failure_rate": schema.Int64Attribute{
+				Description: "The percentage of the probe nodes that fail before an address is considered " +
+					"unavailable. Valid values: 20, 50, 80, 100. Default to 50.",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(50),
+				Validators: []validator.Int64{
+					int64validator.OneOf(20, 50, 80, 100),
+				},
+			},
+			"port": schema.Int64Attribute{
+				Description: "The port to check, for the HTTP, HTTPS and TCP protocols. Required for TCP.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"host": schema.StringAttribute{
+				Description: "The host header of the health checks, for the HTTP and HTTPS protocols.",
+				Optional:    true,
+			},
+			"path": schema.StringAttribute{
+				Description: "The path of the health checks, for the HTTP and HTTPS protocols.",
+				Optional:    true,
+			},
+			"code": schema.Int64Attribute{
+				Description: "The HTTP status code from which a health check fails, for the HTTP and HTTPS " +
+					"protocols. Valid values: 400, 500.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.OneOf(400, 500),
+				},
+			},
+			"sni": schema.BoolAttribute{
+				Description: "Whether to send the server name indication, for the HTTPS protocol.",
+				Optional:    true,
+			},
+			"packet_num": schema.Int64Attribute{
+				Description: "The number of the packets sent per health check, for the PING protocol. " +
+					"Valid values: 20, 50, 100.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.OneOf(20, 50, 100),
+				},
+			},
+			"packet_loss_rate": schema.Int64Attribute{
+				Description: "The packet loss percentage from which a health check fails, for the PING " +
+					"protocol. Valid values: 10, 30, 40, 80, 90, 100.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.OneOf(10, 30, 40, 80, 90, 100),
+				},
+			},
+			"node_type": schema.StringAttribute{
+				Description: "The type of the probe nodes for the address pools of domains. Valid values: " +
+					"IPV4, IPV6. The probe nodes of IPv4 and IPv6 pools follow the type of the pool.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("IPV4", "IPV6"),
+				},
+			},
+			"isp_city_nodes": schema.SetNestedAttribute{
+				Description: "The probe nodes of the health checks. Default to the nodes selected by default " +
+					"for the type of the address pool.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"isp_code": schema.StringAttribute{
+							Description: "The code of the ISP of the probe node.",
+							Required:    true,
+						},
+						"city_code": schema.StringAttribute{
+							Description: "The code of the city of the probe node.",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Whether the health check is enabled. Valid values: OPEN, CLOSE. Default to OPEN.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("OPEN"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("OPEN", "CLOSE"),
+				},
+			},
+			"monitor_config_id": schema.StringAttribute{
+				Description: "The ID of the health check configuration.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *alidnsGtmMonitorResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+func (r *alidnsGtmMonitorResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var protocolType types.String
+	getAttributeDiags := req.Config.GetAttribute(ctx, path.Root("protocol_type"), &protocolType)
+	resp.Diagnostics.Append(getAttributeDiags...)
+	if resp.Diagnostics.HasError() || protocolType.IsUnknown() {
+		return
+	}
+
+	var config struct {
+		Port           types.Int64  `tfsdk:"port"`
+		Host           types.String `tfsdk:"host"`
+		Path           types.String `tfsdk:"path"`
+		Code           types.Int64  `tfsdk:"code"`
+		Sni            types.Bool   `tfsdk:"sni"`
+		PacketNum      types.Int64  `tfsdk:"packet_num"`
+		PacketLossRate types.Int64  `tfsdk:"packet_loss_rate"`
+		Timeout        types.Int64  `tfsdk:"timeout"`
+	}
+	for attribute, value := range map[string]interface{}{
+		"port":             &config.Port,
+		"host":             &config.Host,
+		"path":             &config.Path,
+		"code":             &config.Code,
+		"sni":              &config.Sni,
+		"packet_num":       &config.PacketNum,
+		"packet_loss_rate": &config.PacketLossRate,
+		"timeout":          &config.Timeout,
+	} {
+		getAttributeDiags := req.Config.GetAttribute(ctx, path.Root(attribute), value)
+		resp.Diagnostics.Append(getAttributeDiags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	invalid := func(attribute string, configured bool, protocols ...string) {
+		if !configured {
+			return
+		}
+		for _, protocol := range protocols {
+			if protocol == protocolType.ValueString() {
+				return
+			}
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root(attribute),
+			"[Input Error] Invalid Health Check Attribute",
+			fmt.Sprintf("The %s is only valid for the %s protocols, got %s.", attribute, strings.Join(protocols, ", "), protocolType.ValueString()),
+		)
+	}
+	invalid("port", !config.Port.IsNull(), "HTTP", "HTTPS", "TCP")
+	invalid("host", !config.Host.IsNull(), "HTTP", "HTTPS")
+	invalid("path", !config.Path.IsNull(), "HTTP", "HTTPS")
+	invalid("code", !config.Code.IsNull(), "HTTP", "HTTPS")
+	invalid("sni", !config.Sni.IsNull(), "HTTPS")
+	invalid("packet_num", !config.PacketNum.IsNull(), "PING")
+	invalid("packet_loss_rate", !config.PacketLossRate.IsNull(), "PING")
+
+	if protocolType.ValueString() == "TCP" && config.Port.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("port"),
+			"[Input Error] Missing Health Check Port",
+			"The port must be configured for the TCP protocol.",
+		)
+	}
+	if protocolType.ValueString() == "PING" && config.Timeout.ValueInt64() == 2000 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("timeout"),
+			"[Input Error] Invalid Health Check Timeout",
+			"The timeout of the PING protocol must be one of 3000, 5000, 10000.",
+		)
+	}
+}
+
+// ModifyPlan plans the default probe nodes when they are not configured, so
+// that removing them from the configuration goes back to the default nodes.
+func (r *alidnsGtmMonitorResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Skip on create and destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var configuredNodes types.Set
+	getConfigDiags := req.Config.GetAttribute(ctx, path.Root("isp_city_nodes"), &configuredNodes)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() || !configuredNodes.IsNull() {
+		return
+	}
+
+	var addrPoolId, nodeType types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("addr_pool_id"), &addrPoolId)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("node_type"), &nodeType)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if nodeType.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("isp_city_nodes"), types.SetUnknown(alidnsGtmMonitorIspCityNodeType))...)
+		return
+	}
+
+	addressPool, err := describeAlidnsGtmAddressPool(r.client, addrPoolId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe GTM Address Pool",
+			err.Error(),
+		)
+		return
+	}
+	ispCityNodes, err := r.resolveIspCityNodes(tea.StringValue(addressPool.Type), nodeType.ValueString(), nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe GTM Monitor Available Config",
+			err.Error(),
+		)
+		return
+	}
+	ispCityNodesSet, setNodesDiags := types.SetValueFrom(ctx, alidnsGtmMonitorIspCityNodeType, ispCityNodes)
+	resp.Diagnostics.Append(setNodesDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("isp_city_nodes"), ispCityNodesSet)...)
+}
+
+func (r *alidnsGtmMonitorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *alidnsGtmMonitorResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	addressPool, err := describeAlidnsGtmAddressPool(r.client, plan.AddrPoolId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe GTM Address Pool",
+			err.Error(),
+		)
+		return
+	}
+
+	// The probe nodes are unknown when they are not configured.
+	var configuredNodes []*alidnsGtmMonitorIspCityNode
+	if !plan.IspCityNodes.IsUnknown() && !plan.IspCityNodes.IsNull() {
+		getNodesDiags := plan.IspCityNodes.ElementsAs(ctx, &configuredNodes, false)
+		resp.Diagnostics.Append(getNodesDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	ispCityNodes, err := r.resolveIspCityNodes(tea.StringValue(addressPool.Type), plan.NodeType.ValueString(), configuredNodes)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("isp_city_nodes"),
+			"[Input Error] Invalid Probe Nodes",
+			err.Error(),
+		)
+		return
+	}
+	ispCityNodesSet, setNodesDiags := types.SetValueFrom(ctx, alidnsGtmMonitorIspCityNodeType, ispCityNodes)
+	resp.Diagnostics.Append(setNodesDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.IspCityNodes = ispCityNodesSet
+
+	// An address pool has at most one monitor, which may be created with the
+	// pool, so the existing monitor is updated instead of adding another one.
+	monitorConfigId := tea.StringValue(addressPool.MonitorConfigId)
+	if monitorConfigId == "" {
+		monitorConfigId, err = r.addMonitor(plan, ispCityNodes)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Add GTM Monitor",
+				err.Error(),
+			)
+			return
+		}
+	} else if err := r.updateMonitor(monitorConfigId, plan, ispCityNodes); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update GTM Monitor",
+			err.Error(),
+		)
+		return
+	}
+	plan.MonitorConfigId = types.StringValue(monitorConfigId)
+
+	if err := r.setMonitorStatus(monitorConfigId, plan.Status.ValueString()); err != nil {
+		plan.Status = types.StringValue(tea.StringValue(addressPool.MonitorStatus))
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Set GTM Monitor Status",
+			err.Error(),
+		)
+	}
+
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsGtmMonitorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *alidnsGtmMonitorResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	addressPool, err := describeAlidnsGtmAddressPool(r.client, state.AddrPoolId.ValueString())
+	if err != nil {
+		// The monitor is gone with its address pool.
+		if !state.InstanceId.IsNull() {
+			exists, listErr := isAlidnsGtmAddressPoolExist(r.client, state.InstanceId.ValueString(), state.AddrPoolId.ValueString())
+			if listErr == nil && !exists {
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe GTM Address Pool",
+			err.Error(),
+		)
+		return
+	}
+	if tea.StringValue(addressPool.MonitorConfigId) == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	var monitor *alicloudDnsClient.DescribeDnsGtmMonitorConfigResponseBody
+	describeDnsGtmMonitorConfig := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		describeDnsGtmMonitorConfigRequest := &alicloudDnsClient.DescribeDnsGtmMonitorConfigRequest{
+			MonitorConfigId: addressPool.MonitorConfigId,
+		}
+
+		describeDnsGtmMonitorConfigResponse, err := r.client.DescribeDnsGtmMonitorConfigWithOptions(describeDnsGtmMonitorConfigRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		monitor = describeDnsGtmMonitorConfigResponse.Body
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(describeDnsGtmMonitorConfig, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe GTM Monitor",
+			err.Error(),
+		)
+		return
+	}
+
+	// The optional attributes of the extend info are kept null when they are
+	// not configured, except after an import.
+	imported := state.ProtocolType.IsNull()
+	state.MonitorConfigId = types.StringValue(tea.StringValue(monitor.MonitorConfigId))
+	state.ProtocolType = types.StringValue(tea.StringValue(monitor.ProtocolType))
+	state.Interval = types.Int64Value(int64(tea.Int32Value(monitor.Interval)))
+	state.EvaluationCount = types.Int64Value(int64(tea.Int32Value(monitor.EvaluationCount)))
+	state.Timeout = types.Int64Value(int64(tea.Int32Value(monitor.Timeout)))
+	state.Status = types.StringValue(tea.StringValue(addressPool.MonitorStatus))
+
+	extendInfo := map[string]interface{}{}
+	if tea.StringValue(monitor.MonitorExtendInfo) != "" {
+		if err := json.Unmarshal([]byte(tea.StringValue(monitor.MonitorExtendInfo)), &extendInfo); err != nil {
+			resp.Diagnostics.AddError(
+				"[ERROR] Failed to Parse GTM Monitor Extend Info",
+				err.Error(),
+			)
+			return
+		}
+	}
+	readString := func(old types.String, key string) types.String {
+		value, ok := extendInfo[key]
+		if !ok || (old.IsNull() && !imported) {
+			return old
+		}
+		return types.StringValue(fmt.Sprint(value))
+	}
+	readInt64 := func(old types.Int64, key string) types.Int64 {
+		value, ok := extendInfo[key]
+		if !ok || (old.IsNull() && !imported) {
+			return old
+		}
+		number, err := strconv.ParseInt(fmt.Sprint(value), 10, 64)
+		if err != nil {
+			return old
+		}
+		return types.Int64Value(number)
+	}
+	state.FailureRate = readInt64(state.FailureRate, "failureRate")
+	state.Port = readInt64(state.Port, "port")
+	state.Host = readString(state.Host, "host")
+	state.Path = readString(state.Path, "path")
+	state.Code = readInt64(state.Code, "code")
+	state.PacketNum = readInt64(state.PacketNum, "packetNum")
+	state.PacketLossRate = readInt64(state.PacketLossRate, "packetLossRate")
+	state.NodeType = readString(state.NodeType, "nodeType")
+	if value, ok := extendInfo["sni"]; ok && (!state.Sni.IsNull() || imported) {
+		state.Sni = types.BoolValue(fmt.Sprint(value) == "true")
+	}
+
+	ispCityNodes := []*alidnsGtmMonitorIspCityNode{}
+	if monitor.IspCityNodes != nil {
+		for _, node := range monitor.IspCityNodes.IspCityNode {
+			ispCityNodes = append(ispCityNodes, &alidnsGtmMonitorIspCityNode{
+				IspCode:  types.StringValue(tea.StringValue(node.IspCode)),
+				CityCode: types.StringValue(tea.StringValue(node.CityCode)),
+			})
+		}
+	}
+	ispCityNodesSet, setNodesDiags := types.SetValueFrom(ctx, alidnsGtmMonitorIspCityNodeType, ispCityNodes)
+	resp.Diagnostics.Append(setNodesDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.IspCityNodes = ispCityNodesSet
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsGtmMonitorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *alidnsGtmMonitorResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	addressPool, err := describeAlidnsGtmAddressPool(r.client, state.AddrPoolId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe GTM Address Pool",
+			err.Error(),
+		)
+		return
+	}
+
+	// The probe nodes are unknown when they are not configured.
+	var configuredNodes []*alidnsGtmMonitorIspCityNode
+	if !plan.IspCityNodes.IsUnknown() && !plan.IspCityNodes.IsNull() {
+		getNodesDiags := plan.IspCityNodes.ElementsAs(ctx, &configuredNodes, false)
+		resp.Diagnostics.Append(getNodesDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	ispCityNodes, err := r.resolveIspCityNodes(tea.StringValue(addressPool.Type), plan.NodeType.ValueString(), configuredNodes)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("isp_city_nodes"),
+			"[Input Error] Invalid Probe Nodes",
+			err.Error(),
+		)
+		return
+	}
+	ispCityNodesSet, setNodesDiags := types.SetValueFrom(ctx, alidnsGtmMonitorIspCityNodeType, ispCityNodes)
+	resp.Diagnostics.Append(setNodesDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.IspCityNodes = ispCityNodesSet
+	plan.MonitorConfigId = state.MonitorConfigId
+
+	if err := r.updateMonitor(state.MonitorConfigId.ValueString(), plan, ispCityNodes); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update GTM Monitor",
+			err.Error(),
+		)
+		return
+	}
+
+	if !plan.Status.Equal(state.Status) {
+		if err := r.setMonitorStatus(state.MonitorConfigId.ValueString(), plan.Status.ValueString()); err != nil {
+			plan.Status = state.Status
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Set GTM Monitor Status",
+				err.Error(),
+			)
+		}
+	}
+
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsGtmMonitorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *alidnsGtmMonitorResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The monitor of an address pool cannot be deleted, so the health check
+	// is closed to release the health check task quota.
+	if err := r.setMonitorStatus(state.MonitorConfigId.ValueString(), "CLOSE"); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Set GTM Monitor Status",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *alidnsGtmMonitorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("addr_pool_id"), req, resp)
+}
+
+// buildExtendInfo renders the protocol specific attributes to the JSON string
+// of MonitorExtendInfo.
+func (r *alidnsGtmMonitorResource) buildExtendInfo(plan *alidnsGtmMonitorResourceModel) string {
+	extendInfo := map[string]string{
+		"failureRate": fmt.Sprint(plan.FailureRate.ValueInt64()),
+	}
+	if !plan.Port.IsNull() {
+		extendInfo["port"] = fmt.Sprint(plan.Port.ValueInt64())
+	}
+	if !plan.Host.IsNull() {
+		extendInfo["host"] = plan.Host.ValueString()
+	}
+	if !plan.Path.IsNull() {
+		extendInfo["path"] = plan.Path.ValueString()
+	}
+	if !plan.Code.IsNull() {
+		extendInfo["code"] = fmt.Sprint(plan.Code.ValueInt64())
+	}
+	if !plan.Sni.IsNull() {
+		extendInfo["sni"] = fmt.Sprint(plan.Sni.ValueBool())
+	}
+	if !plan.PacketNum.IsNull() {
+		extendInfo["packetNum"] = fmt.Sprint(plan.PacketNum.ValueInt64())
+	}
+	if !plan.PacketLossRate.IsNull() {
+		extendInfo["packetLossRate"] = fmt.Sprint(plan.PacketLossRate.ValueInt64())
+	}
+	if !plan.NodeType.IsNull() {
+		extendInfo["nodeType"] = plan.NodeType.ValueString()
+	}
+
+	extendInfoJson, _ := json.Marshal(extendInfo)
+	return string(extendInfoJson)
+}
+
+func (r *alidnsGtmMonitorResource) addMonitor(plan *alidnsGtmMonitorResourceModel, ispCityNodes []*alidnsGtmMonitorIspCityNode) (monitorConfigId string, err error) {
+	addDnsGtmMonitorRequest := &alicloudDnsClient.AddDnsGtmMonitorRequest{
+		AddrPoolId:        tea.String(plan.AddrPoolId.ValueString()),
+		ProtocolType:      tea.String(plan.ProtocolType.ValueString()),
+		Interval:          tea.Int32(int32(plan.Interval.ValueInt64())),
+		EvaluationCount:   tea.Int32(int32(plan.EvaluationCount.ValueInt64())),
+		Timeout:           tea.Int32(int32(plan.Timeout.ValueInt64())),
+		MonitorExtendInfo: tea.String(r.buildExtendInfo(plan)),
+	}
+	for _, node := range ispCityNodes {
+		addDnsGtmMonitorRequest.IspCityNode = append(addDnsGtmMonitorRequest.IspCityNode, &alicloudDnsClient.AddDnsGtmMonitorRequestIspCityNode{
+			IspCode:  tea.String(node.IspCode.ValueString()),
+			CityCode: tea.String(node.CityCode.ValueString()),
+		})
+	}
+
+	addDnsGtmMonitor := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		addDnsGtmMonitorResponse, err := r.client.AddDnsGtmMonitorWithOptions(addDnsGtmMonitorRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		monitorConfigId = tea.StringValue(addDnsGtmMonitorResponse.Body.MonitorConfigId)
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(addDnsGtmMonitor, reconnectBackoff)
+	return
+}
+
+func (r *alidnsGtmMonitorResource) updateMonitor(monitorConfigId string, plan *alidnsGtmMonitorResourceModel, ispCityNodes []*alidnsGtmMonitorIspCityNode) error {
+	updateDnsGtmMonitorRequest := &alicloudDnsClient.UpdateDnsGtmMonitorRequest{
+		MonitorConfigId:   tea.String(monitorConfigId),
+		ProtocolType:      tea.String(plan.ProtocolType.ValueString()),
+		Interval:          tea.Int32(int32(plan.Interval.ValueInt64())),
+		EvaluationCount:   tea.Int32(int32(plan.EvaluationCount.ValueInt64())),
+		Timeout:           tea.Int32(int32(plan.Timeout.ValueInt64())),
+		MonitorExtendInfo: tea.String(r.buildExtendInfo(plan)),
+	}
+	for _, node := range ispCityNodes {
+		updateDnsGtmMonitorRequest.IspCityNode = append(updateDnsGtmMonitorRequest.IspCityNode, &alicloudDnsClient.UpdateDnsGtmMonitorRequestIspCityNode{
+			IspCode:  tea.String(node.IspCode.ValueString()),
+			CityCode: tea.String(node.CityCode.ValueString()),
+		})
+	}
+
+	updateDnsGtmMonitor := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		if _, err := r.client.UpdateDnsGtmMonitorWithOptions(updateDnsGtmMonitorRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(updateDnsGtmMonitor, reconnectBackoff)
+}
+
+func (r *alidnsGtmMonitorResource) setMonitorStatus(monitorConfigId, status string) error {
+	setDnsGtmMonitorStatus := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		setDnsGtmMonitorStatusRequest := &alicloudDnsClient.SetDnsGtmMonitorStatusRequest{
+			MonitorConfigId: tea.String(monitorConfigId),
+			Status:          tea.String(status),
+		}
+
+		if _, err := r.client.SetDnsGtmMonitorStatusWithOptions(setDnsGtmMonitorStatusRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(setDnsGtmMonitorStatus, reconnectBackoff)
+}
+
+// resolveIspCityNodes checks the configured probe nodes against the nodes
+// available for the type of the address pool, and returns the nodes selected
+// by default when no nodes are configured.
+func (r *alidnsGtmMonitorResource) resolveIspCityNodes(poolType, nodeType string, configured []*alidnsGtmMonitorIspCityNode) ([]*alidnsGtmMonitorIspCityNode, error) {
+	var availableConfig *alicloudDnsClient.DescribeDnsGtmMonitorAvailableConfigResponseBody
+	describeDnsGtmMonitorAvailableConfig := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		describeDnsGtmMonitorAvailableConfigResponse, err := r.client.DescribeDnsGtmMonitorAvailableConfigWithOptions(&alicloudDnsClient.DescribeDnsGtmMonitorAvailableConfigRequest{}, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		availableConfig = describeDnsGtmMonitorAvailableConfigResponse.Body
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(describeDnsGtmMonitorAvailableConfig, reconnectBackoff); err != nil {
+		return nil, err
+	}
+
+	var nodes interface{}
+	switch {
+	case poolType == "IPV4" && availableConfig.Ipv4IspCityNodes != nil:
+		nodes = availableConfig.Ipv4IspCityNodes.Ipv4IspCityNode
+	case poolType == "IPV6" && availableConfig.Ipv6IspCityNodes != nil:
+		nodes = availableConfig.Ipv6IspCityNodes.Ipv6IspCityNode
+	case poolType == "DOMAIN" && nodeType == "IPV6" && availableConfig.DomainIpv6IspCityNodes != nil:
+		nodes = availableConfig.DomainIpv6IspCityNodes.DomainIpv6IspCityNode
+	case poolType == "DOMAIN" && nodeType != "IPV6" && availableConfig.DomainIpv4IspCityNodes != nil:
+		nodes = availableConfig.DomainIpv4IspCityNodes.DomainIpv4IspCityNode
+	}
+	availableNodes := []*alidnsGtmMonitorAvailableNode{}
+	if nodes != nil {
+		if err := tea.Convert(nodes, &availableNodes); err != nil {
+			return nil, err
+		}
+	}
+
+	if configured == nil {
+		defaultNodes := []*alidnsGtmMonitorIspCityNode{}
+		for _, node := range availableNodes {
+			if tea.BoolValue(node.DefaultSelected) {
+				defaultNodes = append(defaultNodes, &alidnsGtmMonitorIspCityNode{
+					IspCode:  types.StringValue(tea.StringValue(node.IspCode)),
+					CityCode: types.StringValue(tea.StringValue(node.CityCode)),
+				})
+			}
+		}
+		if len(defaultNodes) == 0 {
+			return nil, fmt.Errorf("no probe nodes are selected by default for the %s address pool, configure isp_city_nodes instead", poolType)
+		}
+		return defaultNodes, nil
+	}
+
+	available := map[string]bool{}
+	for _, node := range availableNodes {
+		available[tea.StringValue(node.IspCode)+"|"+tea.StringValue(node.CityCode)] = true
+	}
+	unavailable := []string{}
+	for _, node := range configured {
+		if !available[node.IspCode.ValueString()+"|"+node.CityCode.ValueString()] {
+			unavailable = append(unavailable, fmt.Sprintf("%s/%s", node.IspCode.ValueString(), node.CityCode.ValueString()))
+		}
+	}
+	if len(unavailable) > 0 {
+		return nil, fmt.Errorf("the probe nodes %s (isp_code/city_code) are not available for the %s address pool", strings.Join(unavailable, ", "), poolType)
+	}
+	return configured, nil
+}
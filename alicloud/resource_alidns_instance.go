@@ -496,26 +496,7 @@ func (r *alidnsInstanceResource) ImportState(ctx context.Context, req resource.I
 }
 
 func (r alidnsInstanceResource) setInstanceRenewal(req *alicloudBaseClient.SetRenewalRequest) error {
-	setRenewal := func() error {
-		runtime := &util.RuntimeOptions{}
-		_, err := r.baseClient.SetRenewalWithOptions(req, runtime)
-		if err != nil {
-			if _t, ok := err.(*tea.SDKError); ok {
-				if isAbleToRetry(*_t.Code) {
-					return err
-				} else if *_t.Code == "NotApplicable" {
-					r.baseClient.Endpoint = tea.String("business.ap-southeast-1.aliyuncs.com")
-					return err
-				} else {
-					return backoff.Permanent(err)
-				}
-			}
-		}
-		return nil
-	}
-
-	// Retry backoff
-	reconnectBackoff := backoff.NewExponentialBackOff()
-	reconnectBackoff.MaxElapsedTime = 30 * time.Second
-	return backoff.Retry(setRenewal, reconnectBackoff)
+	return onBssEndpoints(func(endpoint string) error {
+		return setBssInstanceRenewal(r.baseClient, endpoint, req)
+	})
 }
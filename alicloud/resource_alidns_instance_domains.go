@@ -0,0 +1,522 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                = &alidnsInstanceDomainsResource{}
+	_ resource.ResourceWithConfigure   = &alidnsInstanceDomainsResource{}
+	_ resource.ResourceWithImportState = &alidnsInstanceDomainsResource{}
+	_ resource.ResourceWithModifyPlan  = &alidnsInstanceDomainsResource{}
+)
+
+// The binding of more than 100 domains in one request times out, so the
+// domains are bound and unbound in batches of 100.
+const alidnsInstanceDomainsBatchSize = 100
+
+func NewAlidnsInstanceDomainsResource() resource.Resource {
+	return &alidnsInstanceDomainsResource{}
+}
+
+type alidnsInstanceDomainsResource struct {
+	client *alicloudDnsClient.Client
+}
+
+type alidnsInstanceDomainsResourceModel struct {
+	InstanceId    types.String `tfsdk:"instance_id"`
+	Domains       types.Set    `tfsdk:"domains"`
+	DomainNumbers types.Int64  `tfsdk:"domain_numbers"`
+}
+
+func (r *alidnsInstanceDomainsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_instance_domains"
+}
+
+func (r *alidnsInstanceDomainsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides an authoritative set of the domains bound to an Alidns instance. The domains that " +
+			"are bound to the instance but not in the set are unbound, so it should not be used together with " +
+			"the Alidns domain attachment resource for the same instance.",
+		Attributes: map[string]schema.Attribute{
+			"instance_id": schema.StringAttribute{
+				Description: "The ID of the Alidns instance.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domains": schema.SetAttribute{
+				Description: "The domains bound to the instance.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"domain_numbers": schema.Int64Attribute{
+				Description: "The number of the domains that can be bound to the instance.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *alidnsInstanceDomainsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+// ModifyPlan warns when the domains exceed the domain quota of the instance,
+// since the domains over the quota can not be bound.
+func (r *alidnsInstanceDomainsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan *alidnsInstanceDomainsResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.InstanceId.IsUnknown() || plan.Domains.IsUnknown() {
+		return
+	}
+
+	domainNumbers, err := r.describeDomainNumbers(plan.InstanceId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"[API ERROR] Failed to Describe DNS Instance",
+			fmt.Sprintf("The domain quota of the instance can not be checked: %s", err.Error()),
+		)
+		return
+	}
+
+	if domainCount := int64(len(plan.Domains.Elements())); domainCount > domainNumbers {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("domains"),
+			"Domains Exceed Instance Quota",
+			fmt.Sprintf("%d domains are configured, but only %d domains can be bound to the instance %s. "+
+				"The domains over the quota will fail to be bound until the quota is upgraded.",
+				domainCount, domainNumbers, plan.InstanceId.ValueString()),
+		)
+	}
+}
+
+func (r *alidnsInstanceDomainsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *alidnsInstanceDomainsResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The state is not saved when some domains failed, since a tainted
+	// resource would unbind all the domains when it is replaced. Applying
+	// again binds the remaining domains, as the bound domains are skipped.
+	state := r.applyDomains(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsInstanceDomainsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *alidnsInstanceDomainsResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainNumbers, err := r.describeDomainNumbers(state.InstanceId.ValueString())
+	if err != nil {
+		// Remove the state if the instance is not found.
+		if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == ERR_INVALID_DNS_PRODUCT {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe DNS Instance",
+			err.Error(),
+		)
+		return
+	}
+
+	domains, err := r.listDomains(state.InstanceId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Instance Domains",
+			err.Error(),
+		)
+		return
+	}
+
+	domainsSet, diags := types.SetValueFrom(ctx, types.StringType, domains)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Domains = domainsSet
+	state.DomainNumbers = types.Int64Value(domainNumbers)
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsInstanceDomainsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *alidnsInstanceDomainsResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := r.applyDomains(ctx, plan, &resp.Diagnostics)
+	if state == nil {
+		return
+	}
+
+	// Set the state to the domains that are actually bound even when some of
+	// the domains failed.
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsInstanceDomainsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *alidnsInstanceDomainsResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domains := []string{}
+	resp.Diagnostics.Append(state.Domains.ElementsAs(ctx, &domains, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.listDomains(state.InstanceId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Instance Domains",
+			err.Error(),
+		)
+		return
+	}
+
+	toUnbind := intersectAlidnsDomains(domains, current)
+	resp.Diagnostics.Append(r.operateDomains(state.InstanceId.ValueString(), toUnbind, r.unbindDomains, "Unbind")...)
+}
+
+func (r *alidnsInstanceDomainsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("instance_id"), req, resp)
+}
+
+// applyDomains binds and unbinds the difference between the bound domains and
+// the planned domains. The domains are unbound first to free the quota, and
+// the domains over the quota are reported as failed instead of being sent.
+// The returned state has the domains that are actually bound, so that the
+// failed domains are planned again, and is nil when they can not be listed.
+func (r *alidnsInstanceDomainsResource) applyDomains(ctx context.Context, plan *alidnsInstanceDomainsResourceModel, diags *diag.Diagnostics) *alidnsInstanceDomainsResourceModel {
+	instanceId := plan.InstanceId.ValueString()
+
+	domains := []string{}
+	diags.Append(plan.Domains.ElementsAs(ctx, &domains, false)...)
+	if diags.HasError() {
+		return nil
+	}
+
+	domainNumbers, err := r.describeDomainNumbers(instanceId)
+	if err != nil {
+		diags.AddError(
+			"[API ERROR] Failed to Describe DNS Instance",
+			err.Error(),
+		)
+		return nil
+	}
+
+	current, err := r.listDomains(instanceId)
+	if err != nil {
+		diags.AddError(
+			"[API ERROR] Failed to Describe Instance Domains",
+			err.Error(),
+		)
+		return nil
+	}
+
+	toUnbind := subtractAlidnsDomains(current, domains)
+	diags.Append(r.operateDomains(instanceId, toUnbind, r.unbindDomains, "Unbind")...)
+
+	toBind := subtractAlidnsDomains(domains, current)
+	if len(toBind) > 0 {
+		if current, err = r.listDomains(instanceId); err != nil {
+			diags.AddError(
+				"[API ERROR] Failed to Describe Instance Domains",
+				err.Error(),
+			)
+			return nil
+		}
+
+		available := int(domainNumbers) - len(current)
+		if available < 0 {
+			available = 0
+		}
+		if len(toBind) > available {
+			for _, domain := range toBind[available:] {
+				diags.AddError(
+					"[API ERROR] Failed to Bind Domain",
+					fmt.Sprintf("Domain %s: the instance %s has no quota left, only %d domains can be bound.", domain, instanceId, domainNumbers),
+				)
+			}
+			toBind = toBind[:available]
+		}
+		diags.Append(r.operateDomains(instanceId, toBind, r.bindDomains, "Bind")...)
+	}
+
+	if current, err = r.listDomains(instanceId); err != nil {
+		diags.AddError(
+			"[API ERROR] Failed to Describe Instance Domains",
+			err.Error(),
+		)
+		return nil
+	}
+	domainsSet, setDiags := types.SetValueFrom(ctx, types.StringType, current)
+	diags.Append(setDiags...)
+	if setDiags.HasError() {
+		return nil
+	}
+
+	newState := &alidnsInstanceDomainsResourceModel{
+		InstanceId:    plan.InstanceId,
+		Domains:       domainsSet,
+		DomainNumbers: types.Int64Value(domainNumbers),
+	}
+	return newState
+}
+
+// operateDomains binds or unbinds the domains in batches. When some domains
+// of a batch failed, the domains that are not bound or unbound yet are sent
+// one by one, so that the reason of each failed domain is reported.
+func (r *alidnsInstanceDomainsResource) operateDomains(instanceId string, domains []string, operate func(instanceId string, domains []string) (int32, error), operation string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for start := 0; start < len(domains); start += alidnsInstanceDomainsBatchSize {
+		end := start + alidnsInstanceDomainsBatchSize
+		if end > len(domains) {
+			end = len(domains)
+		}
+		batch := domains[start:end]
+
+		failedCount, err := operate(instanceId, batch)
+		if err == nil && failedCount == 0 {
+			continue
+		}
+
+		current, listErr := r.listDomains(instanceId)
+		if listErr != nil {
+			diags.AddError(
+				fmt.Sprintf("[API ERROR] Failed to %s Domains", operation),
+				fmt.Sprintf("Domains %s: %s", strings.Join(batch, ", "), listErr.Error()),
+			)
+			continue
+		}
+
+		var pending []string
+		if operation == "Bind" {
+			pending = subtractAlidnsDomains(batch, current)
+		} else {
+			pending = intersectAlidnsDomains(batch, current)
+		}
+		for _, domain := range pending {
+			failedCount, err := operate(instanceId, []string{domain})
+			if err == nil && failedCount > 0 {
+				err = fmt.Errorf("the domain is rejected by the instance")
+			}
+			if err != nil {
+				diags.AddError(
+					fmt.Sprintf("[API ERROR] Failed to %s Domain", operation),
+					fmt.Sprintf("Domain %s: %s", domain, err.Error()),
+				)
+			}
+		}
+	}
+	return diags
+}
+
+func (r *alidnsInstanceDomainsResource) bindDomains(instanceId string, domains []string) (failedCount int32, err error) {
+	bindInstanceDomains := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		bindInstanceDomainsRequest := &alicloudDnsClient.BindInstanceDomainsRequest{
+			InstanceId:  tea.String(instanceId),
+			DomainNames: tea.String(strings.Join(domains, ",")),
+		}
+
+		bindInstanceDomainsResponse, err := r.client.BindInstanceDomainsWithOptions(bindInstanceDomainsRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		failedCount = tea.Int32Value(bindInstanceDomainsResponse.Body.FailedCount)
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(bindInstanceDomains, reconnectBackoff)
+	return
+}
+
+func (r *alidnsInstanceDomainsResource) unbindDomains(instanceId string, domains []string) (failedCount int32, err error) {
+	unbindInstanceDomains := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		unbindInstanceDomainsRequest := &alicloudDnsClient.UnbindInstanceDomainsRequest{
+			InstanceId:  tea.String(instanceId),
+			DomainNames: tea.String(strings.Join(domains, ",")),
+		}
+
+		unbindInstanceDomainsResponse, err := r.client.UnbindInstanceDomainsWithOptions(unbindInstanceDomainsRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		failedCount = tea.Int32Value(unbindInstanceDomainsResponse.Body.FailedCount)
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(unbindInstanceDomains, reconnectBackoff)
+	return
+}
+
+// describeDomainNumbers returns the number of the domains that can be bound
+// to the instance.
+func (r *alidnsInstanceDomainsResource) describeDomainNumbers(instanceId string) (domainNumbers int64, err error) {
+	describeDnsProductInstance := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		describeDnsProductInstanceRequest := &alicloudDnsClient.DescribeDnsProductInstanceRequest{
+			InstanceId: tea.String(instanceId),
+		}
+
+		describeDnsProductInstanceResponse, err := r.client.DescribeDnsProductInstanceWithOptions(describeDnsProductInstanceRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		domainNumbers = tea.Int64Value(describeDnsProductInstanceResponse.Body.BindDomainCount)
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(describeDnsProductInstance, reconnectBackoff)
+	return
+}
+
+// listDomains returns all the domains bound to the instance, paging through
+// DescribeInstanceDomains.
+func (r *alidnsInstanceDomainsResource) listDomains(instanceId string) (domains []string, err error) {
+	describeInstanceDomains := func() error {
+		runtime := &util.RuntimeOptions{}
+		domains = []string{}
+
+		describeInstanceDomainsRequest := &alicloudDnsClient.DescribeInstanceDomainsRequest{
+			InstanceId: tea.String(instanceId),
+			PageNumber: tea.Int64(1),
+			PageSize:   tea.Int64(100),
+		}
+
+		for {
+			describeInstanceDomainsResponse, err := r.client.DescribeInstanceDomainsWithOptions(describeInstanceDomainsRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+
+			instanceDomains := describeInstanceDomainsResponse.Body.InstanceDomains
+			if len(instanceDomains) == 0 {
+				break
+			}
+			for _, instanceDomain := range instanceDomains {
+				domains = append(domains, tea.StringValue(instanceDomain.DomainName))
+			}
+
+			if len(domains) >= int(tea.Int32Value(describeInstanceDomainsResponse.Body.TotalItems)) {
+				break
+			}
+			describeInstanceDomainsRequest.PageNumber = tea.Int64(tea.Int64Value(describeInstanceDomainsRequest.PageNumber) + 1)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(describeInstanceDomains, reconnectBackoff)
+	return
+}
+
+// subtractAlidnsDomains returns the sorted domains of a that are not in b.
+func subtractAlidnsDomains(a, b []string) []string {
+	exclude := map[string]bool{}
+	for _, domain := range b {
+		exclude[strings.ToLower(domain)] = true
+	}
+	result := []string{}
+	for _, domain := range a {
+		if !exclude[strings.ToLower(domain)] {
+			result = append(result, domain)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// intersectAlidnsDomains returns the sorted domains of a that are also in b.
+func intersectAlidnsDomains(a, b []string) []string {
+	include := map[string]bool{}
+	for _, domain := range b {
+		include[strings.ToLower(domain)] = true
+	}
+	result := []string{}
+	for _, domain := range a {
+		if include[strings.ToLower(domain)] {
+			result = append(result, domain)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
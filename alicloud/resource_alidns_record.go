@@ -0,0 +1,696 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                   = &alidnsRecordResource{}
+	_ resource.ResourceWithConfigure      = &alidnsRecordResource{}
+	_ resource.ResourceWithImportState    = &alidnsRecordResource{}
+	_ resource.ResourceWithValidateConfig = &alidnsRecordResource{}
+)
+
+func getAlidnsRecordTypes() []string {
+	return []string{"A", "AAAA", "CNAME", "MX", "TXT", "SRV", "CAA"}
+}
+
+func NewAlidnsRecordResource() resource.Resource {
+	return &alidnsRecordResource{}
+}
+
+type alidnsRecordResource struct {
+	client *alicloudDnsClient.Client
+}
+
+type alidnsRecordResourceModel struct {
+	DomainName types.String `tfsdk:"domain_name"`
+	RR         types.String `tfsdk:"rr"`
+	Type       types.String `tfsdk:"type"`
+	Value      types.String `tfsdk:"value"`
+	TTL        types.Int64  `tfsdk:"ttl"`
+	Line       types.String `tfsdk:"line"`
+	Priority   types.Int64  `tfsdk:"priority"`
+	Status     types.String `tfsdk:"status"`
+	Remark     types.String `tfsdk:"remark"`
+	RecordId   types.String `tfsdk:"record_id"`
+}
+
+func (r *alidnsRecordResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_record"
+}
+
+func (r *alidnsRecordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides an Alidns record resource. The weight of the record can be managed " +
+			"with the Alidns record weight resource by its record ID.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description: "The domain name that the record belongs to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rr": schema.StringAttribute{
+				Description: "The host record of the record, e.g. www, or @ for the domain itself.",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "The type of the record. Valid values: A, AAAA, CNAME, MX, TXT, SRV, CAA.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(getAlidnsRecordTypes()...),
+				},
+			},
+			"value": schema.StringAttribute{
+				Description: "The value of the record. SRV records are in the format of <priority> <weight> <port> <target> " +
+					"and CAA records are in the format of <flags> <tag> \"<value>\".",
+				Required: true,
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "The TTL of the record in seconds. Default to 600.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 86400),
+				},
+				Default: int64default.StaticInt64(600),
+			},
+			"line": schema.StringAttribute{
+				Description: "The resolution line of the record. Default to default.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("default"),
+			},
+			"priority": schema.Int64Attribute{
+				Description: "The priority of the MX record. Valid values: 1 to 50. Required for and only valid for MX records.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 50),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "The status of the record. Valid values: ENABLE, DISABLE. Default to ENABLE.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ENABLE", "DISABLE"),
+				},
+				Default: stringdefault.StaticString("ENABLE"),
+			},
+			"remark": schema.StringAttribute{
+				Description: "The remark of the record.",
+				Optional:    true,
+			},
+			"record_id": schema.StringAttribute{
+				Description: "The ID of the record.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *alidnsRecordResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+func (r *alidnsRecordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *alidnsRecordResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The values can only be validated when they are known.
+	if config.Type.IsUnknown() || config.Type.IsNull() {
+		return
+	}
+	recordType := config.Type.ValueString()
+
+	if !config.Value.IsUnknown() && !config.Value.IsNull() {
+		if err := validateAlidnsRecordValue(recordType, config.Value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("value"),
+				"[Input Error] Invalid Record Value",
+				err.Error(),
+			)
+		}
+	}
+
+	if recordType == "MX" && config.Priority.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("priority"),
+			"[Input Error] Missing Record Priority",
+			"The priority must be configured for MX records.",
+		)
+	}
+	if recordType != "MX" && !config.Priority.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("priority"),
+			"[Input Error] Invalid Record Priority",
+			fmt.Sprintf("The priority is only valid for MX records, not %s records.", recordType),
+		)
+	}
+}
+
+func (r *alidnsRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *alidnsRecordResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var recordId string
+	addDomainRecord := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		addDomainRecordRequest := &alicloudDnsClient.AddDomainRecordRequest{
+			DomainName: tea.String(plan.DomainName.ValueString()),
+			RR:         tea.String(plan.RR.ValueString()),
+			Type:       tea.String(plan.Type.ValueString()),
+			Value:      tea.String(plan.Value.ValueString()),
+			TTL:        tea.Int64(plan.TTL.ValueInt64()),
+			Line:       tea.String(plan.Line.ValueString()),
+		}
+		if !plan.Priority.IsNull() {
+			addDomainRecordRequest.Priority = tea.Int64(plan.Priority.ValueInt64())
+		}
+
+		addDomainRecordResponse, err := r.client.AddDomainRecordWithOptions(addDomainRecordRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		recordId = tea.StringValue(addDomainRecordResponse.Body.RecordId)
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(addDomainRecord, reconnectBackoff)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Add Domain Record",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &alidnsRecordResourceModel{
+		DomainName: plan.DomainName,
+		RR:         plan.RR,
+		Type:       plan.Type,
+		Value:      plan.Value,
+		TTL:        plan.TTL,
+		Line:       plan.Line,
+		Priority:   plan.Priority,
+		Status:     types.StringValue("ENABLE"),
+		Remark:     types.StringNull(),
+		RecordId:   types.StringValue(recordId),
+	}
+
+	// Save the record to the state first, so that it is not orphaned when the
+	// status or the remark fails to be set.
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Status.ValueString() != "ENABLE" {
+		if err := r.setRecordStatus(recordId, plan.Status.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Set Domain Record Status",
+				err.Error(),
+			)
+			return
+		}
+		state.Status = plan.Status
+	}
+
+	if !plan.Remark.IsNull() {
+		if err := r.setRecordRemark(recordId, plan.Remark.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Set Domain Record Remark",
+				err.Error(),
+			)
+			return
+		}
+		state.Remark = plan.Remark
+	}
+
+	setStateDiags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *alidnsRecordResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	record, err := describeAlidnsRecord(r.client, state.RecordId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read Domain Record",
+			err.Error(),
+		)
+		return
+	}
+	if record == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.DomainName = types.StringValue(tea.StringValue(record.DomainName))
+	state.RR = types.StringValue(tea.StringValue(record.RR))
+	state.Type = types.StringValue(tea.StringValue(record.Type))
+	state.Value = types.StringValue(tea.StringValue(record.Value))
+	state.TTL = types.Int64Value(tea.Int64Value(record.TTL))
+	state.Line = types.StringValue(tea.StringValue(record.Line))
+	state.Status = types.StringValue(tea.StringValue(record.Status))
+	if tea.StringValue(record.Type) == "MX" {
+		state.Priority = types.Int64Value(tea.Int64Value(record.Priority))
+	} else {
+		state.Priority = types.Int64Null()
+	}
+	if remark := tea.StringValue(record.Remark); remark != "" || !state.Remark.IsNull() {
+		state.Remark = types.StringValue(remark)
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *alidnsRecordResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordId := state.RecordId.ValueString()
+
+	// UpdateDomainRecord fails when nothing is changed, so it is only called
+	// when the record itself is changed.
+	if !plan.RR.Equal(state.RR) ||
+		!plan.Type.Equal(state.Type) ||
+		!plan.Value.Equal(state.Value) ||
+		!plan.TTL.Equal(state.TTL) ||
+		!plan.Line.Equal(state.Line) ||
+		!plan.Priority.Equal(state.Priority) {
+		updateDomainRecord := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			updateDomainRecordRequest := &alicloudDnsClient.UpdateDomainRecordRequest{
+				RecordId: tea.String(recordId),
+				RR:       tea.String(plan.RR.ValueString()),
+				Type:     tea.String(plan.Type.ValueString()),
+				Value:    tea.String(plan.Value.ValueString()),
+				TTL:      tea.Int64(plan.TTL.ValueInt64()),
+				Line:     tea.String(plan.Line.ValueString()),
+			}
+			if !plan.Priority.IsNull() {
+				updateDomainRecordRequest.Priority = tea.Int64(plan.Priority.ValueInt64())
+			}
+
+			if _, err := r.client.UpdateDomainRecordWithOptions(updateDomainRecordRequest, runtime); err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		err := backoff.Retry(updateDomainRecord, reconnectBackoff)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Update Domain Record",
+				err.Error(),
+			)
+			return
+		}
+
+		state.RR = plan.RR
+		state.Type = plan.Type
+		state.Value = plan.Value
+		state.TTL = plan.TTL
+		state.Line = plan.Line
+		state.Priority = plan.Priority
+	}
+
+	if !plan.Status.Equal(state.Status) {
+		if err := r.setRecordStatus(recordId, plan.Status.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Set Domain Record Status",
+				err.Error(),
+			)
+			return
+		}
+		state.Status = plan.Status
+	}
+
+	if !plan.Remark.Equal(state.Remark) {
+		if err := r.setRecordRemark(recordId, plan.Remark.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Set Domain Record Remark",
+				err.Error(),
+			)
+			return
+		}
+		state.Remark = plan.Remark
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *alidnsRecordResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteDomainRecord := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		deleteDomainRecordRequest := &alicloudDnsClient.DeleteDomainRecordRequest{
+			RecordId: tea.String(state.RecordId.ValueString()),
+		}
+
+		if _, err := r.client.DeleteDomainRecordWithOptions(deleteDomainRecordRequest, runtime); err != nil {
+			if isAlidnsRecordNotFound(err) {
+				return nil
+			}
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(deleteDomainRecord, reconnectBackoff)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Delete Domain Record",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *alidnsRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	domainName, recordId, found := strings.Cut(req.ID, ":")
+	if !found || domainName == "" || recordId == "" {
+		resp.Diagnostics.AddError(
+			"[Input Error] Invalid Import ID",
+			fmt.Sprintf("The import ID must be in the format of <domain name>:<record ID>, got %q.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("record_id"), recordId)...)
+}
+
+// setRecordStatus enables or disables the record, the status is either
+// ENABLE or DISABLE.
+func (r *alidnsRecordResource) setRecordStatus(recordId, status string) error {
+	setDomainRecordStatus := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		setDomainRecordStatusRequest := &alicloudDnsClient.SetDomainRecordStatusRequest{
+			RecordId: tea.String(recordId),
+			Status:   tea.String("Enable"),
+		}
+		if status == "DISABLE" {
+			setDomainRecordStatusRequest.Status = tea.String("Disable")
+		}
+
+		if _, err := r.client.SetDomainRecordStatusWithOptions(setDomainRecordStatusRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(setDomainRecordStatus, reconnectBackoff)
+}
+
+func (r *alidnsRecordResource) setRecordRemark(recordId, remark string) error {
+	updateDomainRecordRemark := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		updateDomainRecordRemarkRequest := &alicloudDnsClient.UpdateDomainRecordRemarkRequest{
+			RecordId: tea.String(recordId),
+			Remark:   tea.String(remark),
+		}
+
+		if _, err := r.client.UpdateDomainRecordRemarkWithOptions(updateDomainRecordRemarkRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(updateDomainRecordRemark, reconnectBackoff)
+}
+
+// describeAlidnsRecord returns the record with the record ID, or nil if the
+// record does not exist. DescribeDomainRecordInfo does not return the remark,
+// so the record is looked up again in the records of its subdomain.
+func describeAlidnsRecord(client *alicloudDnsClient.Client, recordId string) (*alicloudDnsClient.DescribeSubDomainRecordsResponseBodyDomainRecordsRecord, error) {
+	var recordInfo *alicloudDnsClient.DescribeDomainRecordInfoResponseBody
+	describeDomainRecordInfo := func() error {
+		runtime := &util.RuntimeOptions{}
+		recordInfo = nil
+
+		describeDomainRecordInfoRequest := &alicloudDnsClient.DescribeDomainRecordInfoRequest{
+			RecordId: tea.String(recordId),
+		}
+
+		describeDomainRecordInfoResponse, err := client.DescribeDomainRecordInfoWithOptions(describeDomainRecordInfoRequest, runtime)
+		if err != nil {
+			if isAlidnsRecordNotFound(err) {
+				return nil
+			}
+			return handleAPIError(err)
+		}
+		recordInfo = describeDomainRecordInfoResponse.Body
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(describeDomainRecordInfo, reconnectBackoff); err != nil {
+		return nil, err
+	}
+	if recordInfo == nil {
+		return nil, nil
+	}
+
+	records, err := listAlidnsSubDomainRecords(client, tea.StringValue(recordInfo.DomainName), tea.StringValue(recordInfo.RR), tea.StringValue(recordInfo.Type))
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		if tea.StringValue(record.RecordId) == recordId {
+			return record, nil
+		}
+	}
+
+	// Fall back to the record info without the remark.
+	return &alicloudDnsClient.DescribeSubDomainRecordsResponseBodyDomainRecordsRecord{
+		DomainName: recordInfo.DomainName,
+		Line:       recordInfo.Line,
+		Locked:     recordInfo.Locked,
+		Priority:   recordInfo.Priority,
+		RR:         recordInfo.RR,
+		RecordId:   recordInfo.RecordId,
+		Status:     recordInfo.Status,
+		TTL:        recordInfo.TTL,
+		Type:       recordInfo.Type,
+		Value:      recordInfo.Value,
+	}, nil
+}
+
+// listAlidnsSubDomainRecords returns all the records of the subdomain with the
+// record type, paging through DescribeSubDomainRecords. An empty record type
+// returns the records of all types.
+func listAlidnsSubDomainRecords(client *alicloudDnsClient.Client, domainName, rr, recordType string) (records []*alicloudDnsClient.DescribeSubDomainRecordsResponseBodyDomainRecordsRecord, err error) {
+	subDomain := alidnsSubDomainName(domainName, rr)
+
+	describeSubDomainRecords := func() error {
+		runtime := &util.RuntimeOptions{}
+		records = []*alicloudDnsClient.DescribeSubDomainRecordsResponseBodyDomainRecordsRecord{}
+
+		describeSubDomainRecordsRequest := &alicloudDnsClient.DescribeSubDomainRecordsRequest{
+			DomainName: tea.String(domainName),
+			SubDomain:  tea.String(subDomain),
+			PageNumber: tea.Int64(1),
+			PageSize:   tea.Int64(500),
+		}
+		if recordType != "" {
+			describeSubDomainRecordsRequest.Type = tea.String(recordType)
+		}
+
+		for {
+			describeSubDomainRecordsResponse, err := client.DescribeSubDomainRecordsWithOptions(describeSubDomainRecordsRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+
+			domainRecords := describeSubDomainRecordsResponse.Body.DomainRecords
+			if domainRecords == nil || len(domainRecords.Record) == 0 {
+				break
+			}
+			records = append(records, domainRecords.Record...)
+
+			if int64(len(records)) >= tea.Int64Value(describeSubDomainRecordsResponse.Body.TotalCount) {
+				break
+			}
+			describeSubDomainRecordsRequest.PageNumber = tea.Int64(tea.Int64Value(describeSubDomainRecordsRequest.PageNumber) + 1)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(describeSubDomainRecords, reconnectBackoff)
+	return
+}
+
+func isAlidnsRecordNotFound(err error) bool {
+	if _t, ok := err.(*tea.SDKError); ok {
+		switch tea.StringValue(_t.Code) {
+		case ERR_INVALID_RR_NO_EXIST, ERR_DOMAIN_RECORD_NOT_BELONG_TO_USER:
+			return true
+		}
+	}
+	return false
+}
+
+// validateAlidnsRecordValue checks that the value is valid for the record
+// type, so that the invalid records are rejected at plan time.
+func validateAlidnsRecordValue(recordType, value string) error {
+	if value == "" {
+		return fmt.Errorf("the value of %s records can not be empty", recordType)
+	}
+
+	switch recordType {
+	case "A":
+		if ip := net.ParseIP(value); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("the value of A records must be an IPv4 address, got %q", value)
+		}
+	case "AAAA":
+		if ip := net.ParseIP(value); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("the value of AAAA records must be an IPv6 address, got %q", value)
+		}
+	case "CNAME", "MX":
+		if net.ParseIP(value) != nil || !isValidAlidnsHostname(value) {
+			return fmt.Errorf("the value of %s records must be a domain name, got %q", recordType, value)
+		}
+	case "TXT":
+		if len(value) > 512 {
+			return fmt.Errorf("the value of TXT records can not be longer than 512 characters")
+		}
+	case "SRV":
+		fields := strings.Fields(value)
+		if len(fields) != 4 {
+			return fmt.Errorf("the value of SRV records must be in the format of <priority> <weight> <port> <target>, got %q", value)
+		}
+		for i, name := range []string{"priority", "weight", "port"} {
+			if n, err := strconv.Atoi(fields[i]); err != nil || n < 0 || n > 65535 {
+				return fmt.Errorf("the %s of SRV records must be an integer between 0 and 65535, got %q", name, fields[i])
+			}
+		}
+		if !isValidAlidnsHostname(fields[3]) {
+			return fmt.Errorf("the target of SRV records must be a domain name, got %q", fields[3])
+		}
+	case "CAA":
+		fields := strings.SplitN(value, " ", 3)
+		if len(fields) != 3 {
+			return fmt.Errorf("the value of CAA records must be in the format of <flags> <tag> \"<value>\", got %q", value)
+		}
+		if n, err := strconv.Atoi(fields[0]); err != nil || n < 0 || n > 255 {
+			return fmt.Errorf("the flags of CAA records must be an integer between 0 and 255, got %q", fields[0])
+		}
+		switch fields[1] {
+		case "issue", "issuewild", "iodef":
+		default:
+			return fmt.Errorf("the tag of CAA records must be one of issue, issuewild and iodef, got %q", fields[1])
+		}
+		if len(fields[2]) < 2 || !strings.HasPrefix(fields[2], "\"") || !strings.HasSuffix(fields[2], "\"") {
+			return fmt.Errorf("the value of CAA records must be quoted, got %s", fields[2])
+		}
+	}
+	return nil
+}
+
+// isValidAlidnsHostname checks that the name consists of valid labels, the
+// trailing dot of a fully qualified name is allowed.
+func isValidAlidnsHostname(name string) bool {
+	name = strings.TrimSuffix(name, ".")
+	if name == "" || len(name) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '*') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,659 @@
+package alicloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                   = &alidnsRecordBatchResource{}
+	_ resource.ResourceWithConfigure      = &alidnsRecordBatchResource{}
+	_ resource.ResourceWithValidateConfig = &alidnsRecordBatchResource{}
+)
+
+const (
+	alidnsBatchSize = 500
+
+	alidnsRecordBatchDefaultTTL  = 600
+	alidnsRecordBatchDefaultLine = "default"
+)
+
+func NewAlidnsRecordBatchResource() resource.Resource {
+	return &alidnsRecordBatchResource{}
+}
+
+type alidnsRecordBatchResource struct {
+	client *alicloudDnsClient.Client
+}
+
+type alidnsRecordBatchResourceModel struct {
+	DomainName types.String               `tfsdk:"domain_name"`
+	Records    []*alidnsRecordBatchRecord `tfsdk:"records"`
+}
+
+type alidnsRecordBatchRecord struct {
+	RR       types.String `tfsdk:"rr"`
+	Type     types.String `tfsdk:"type"`
+	Value    types.String `tfsdk:"value"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Line     types.String `tfsdk:"line"`
+	Priority types.Int64  `tfsdk:"priority"`
+}
+
+// key identifies the record in the domain. The TTL and the priority are not
+// part of the key, so that they are updated in place.
+func (r *alidnsRecordBatchRecord) key() string {
+	return strings.Join([]string{strings.ToLower(r.RR.ValueString()), r.Type.ValueString(), r.Value.ValueString(), r.line()}, "|")
+}
+
+func (r *alidnsRecordBatchRecord) ttl() int64 {
+	if r.TTL.IsNull() {
+		return alidnsRecordBatchDefaultTTL
+	}
+	return r.TTL.ValueInt64()
+}
+
+func (r *alidnsRecordBatchRecord) line() string {
+	if r.Line.IsNull() {
+		return alidnsRecordBatchDefaultLine
+	}
+	return r.Line.ValueString()
+}
+
+func (r *alidnsRecordBatchResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_record_batch"
+}
+
+func (r *alidnsRecordBatchResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a set of records of an Alidns domain with batch operations, so that thousands of " +
+			"records are added and deleted in a few batch tasks instead of one API call for each record. " +
+			"Only the records in the set are managed, the other records of the domain are left untouched.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description: "The domain name that the records belong to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"records": schema.SetNestedAttribute{
+				Description: "The records of the domain.",
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"rr": schema.StringAttribute{
+							Description: "The host record of the record, e.g. www, or @ for the domain itself.",
+							Required:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The type of the record. Valid values: A, AAAA, CNAME, MX, TXT, SRV, CAA.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(getAlidnsRecordTypes()...),
+							},
+						},
+						"value": schema.StringAttribute{
+							Description: "The value of the record.",
+							Required:    true,
+						},
+						"ttl": schema.Int64Attribute{
+							Description: "The TTL of the record in seconds. The TTL is 600 when it is not set.",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.Between(1, 86400),
+							},
+						},
+						"line": schema.StringAttribute{
+							Description: "The resolution line of the record. The line is default when it is not set.",
+							Optional:    true,
+						},
+						"priority": schema.Int64Attribute{
+							Description: "The priority of the MX record. Valid values: 1 to 50. Required for and only valid for MX records.",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.Between(1, 50),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *alidnsRecordBatchResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+func (r *alidnsRecordBatchResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// The records can only be validated when the set and its elements are known.
+	var records types.Set
+	getAttributeDiags := req.Config.GetAttribute(ctx, path.Root("records"), &records)
+	resp.Diagnostics.Append(getAttributeDiags...)
+	if resp.Diagnostics.HasError() || records.IsUnknown() {
+		return
+	}
+	for _, element := range records.Elements() {
+		if element.IsUnknown() {
+			return
+		}
+	}
+
+	var config *alidnsRecordBatchResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys := map[string]bool{}
+	for _, record := range config.Records {
+		// The records can only be validated when they are known.
+		if record.RR.IsUnknown() || record.Type.IsUnknown() || record.Value.IsUnknown() || record.Line.IsUnknown() {
+			continue
+		}
+		recordType := record.Type.ValueString()
+		name := fmt.Sprintf("%s %s %s", record.RR.ValueString(), recordType, record.Value.ValueString())
+
+		if err := validateAlidnsRecordValue(recordType, record.Value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("records"),
+				"[Input Error] Invalid Record Value",
+				fmt.Sprintf("Record %s: %s", name, err.Error()),
+			)
+		}
+
+		if recordType == "MX" && record.Priority.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("records"),
+				"[Input Error] Missing Record Priority",
+				fmt.Sprintf("Record %s: the priority must be configured for MX records.", name),
+			)
+		}
+		if recordType != "MX" && !record.Priority.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("records"),
+				"[Input Error] Invalid Record Priority",
+				fmt.Sprintf("Record %s: the priority is only valid for MX records, not %s records.", name, recordType),
+			)
+		}
+
+		if keys[record.key()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("records"),
+				"[Input Error] Duplicate Record",
+				fmt.Sprintf("Record %s on line %s is configured more than once with different TTLs or priorities.", name, record.line()),
+			)
+		}
+		keys[record.key()] = true
+	}
+}
+
+func (r *alidnsRecordBatchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *alidnsRecordBatchResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The records that already exist are taken over instead of being added
+	// again, so that a failed apply can be retried.
+	resp.Diagnostics.Append(r.applyRecords(plan.DomainName.ValueString(), nil, plan.Records)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := &alidnsRecordBatchResourceModel{
+		DomainName: plan.DomainName,
+		Records:    plan.Records,
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsRecordBatchResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *alidnsRecordBatchResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.listRecords(state.DomainName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Domain Records",
+			err.Error(),
+		)
+		return
+	}
+
+	records := []*alidnsRecordBatchRecord{}
+	for _, record := range state.Records {
+		currentRecord, ok := current[record.key()]
+		if !ok {
+			continue
+		}
+		if !(record.TTL.IsNull() && currentRecord.ttl() == alidnsRecordBatchDefaultTTL) {
+			record.TTL = currentRecord.TTL
+		}
+		if !record.Priority.IsNull() {
+			record.Priority = currentRecord.Priority
+		}
+		records = append(records, record)
+	}
+
+	if len(records) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.Records = records
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsRecordBatchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *alidnsRecordBatchResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.applyRecords(plan.DomainName.ValueString(), state.Records, plan.Records)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Records = plan.Records
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsRecordBatchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *alidnsRecordBatchResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.applyRecords(state.DomainName.ValueString(), state.Records, nil)...)
+}
+
+// applyRecords replaces the old records of the resource with the new records.
+// The new records are added before the old records are deleted, so that the
+// names keep resolving, except the old records conflicting with the added
+// records, e.g. an A record replaced by a CNAME record, which are deleted
+// first. The failed records are reported one diagnostic each.
+func (r *alidnsRecordBatchResource) applyRecords(domainName string, oldRecords, newRecords []*alidnsRecordBatchRecord) diag.Diagnostics {
+	current, err := r.listRecords(domainName)
+	if err != nil {
+		return diag.Diagnostics{
+			diag.NewErrorDiagnostic(
+				"[API ERROR] Failed to Describe Domain Records",
+				err.Error(),
+			),
+		}
+	}
+
+	newKeys := map[string]bool{}
+	toAdd := []*alidnsRecordBatchRecord{}
+	toUpdate := []*alidnsRecordBatchRecord{}
+	for _, record := range newRecords {
+		newKeys[record.key()] = true
+		currentRecord, ok := current[record.key()]
+		if !ok {
+			toAdd = append(toAdd, record)
+			continue
+		}
+		if currentRecord.ttl() != record.ttl() || (!record.Priority.IsNull() && !currentRecord.Priority.Equal(record.Priority)) {
+			toUpdate = append(toUpdate, record)
+		}
+	}
+
+	conflicting := []*alidnsRecordBatchRecord{}
+	toDelete := []*alidnsRecordBatchRecord{}
+	for _, record := range oldRecords {
+		if _, ok := current[record.key()]; !ok || newKeys[record.key()] {
+			continue
+		}
+		conflicted := false
+		for _, added := range toAdd {
+			if record.line() == added.line() &&
+				isAlidnsRecordConflict(record.RR.ValueString(), record.Type.ValueString(), added.RR.ValueString(), added.Type.ValueString()) {
+				conflicted = true
+				break
+			}
+		}
+		if conflicted {
+			conflicting = append(conflicting, record)
+		} else {
+			toDelete = append(toDelete, record)
+		}
+	}
+
+	if err := operateAlidnsBatch(r.client, "RR_DEL", alidnsRecordBatchRecordInfos(domainName, conflicting)); err != nil {
+		return alidnsBatchErrorDiagnostics("[API ERROR] Failed to Delete Conflicting Domain Records", err)
+	}
+
+	var diags diag.Diagnostics
+	for _, record := range toUpdate {
+		recordId := current[record.key()].recordId
+		if err := r.updateRecordTtl(recordId, record); err != nil {
+			diags.AddError(
+				"[API ERROR] Failed to Update Domain Record",
+				fmt.Sprintf("Record %s %s %s: %s", record.RR.ValueString(), record.Type.ValueString(), record.Value.ValueString(), err.Error()),
+			)
+		}
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := operateAlidnsBatch(r.client, "RR_ADD", alidnsRecordBatchRecordInfos(domainName, toAdd)); err != nil {
+		return alidnsBatchErrorDiagnostics("[API ERROR] Failed to Add Domain Records", err)
+	}
+
+	if err := operateAlidnsBatch(r.client, "RR_DEL", alidnsRecordBatchRecordInfos(domainName, toDelete)); err != nil {
+		return alidnsBatchErrorDiagnostics("[API ERROR] Failed to Delete Domain Records", err)
+	}
+	return nil
+}
+
+// alidnsRecordBatchCurrentRecord is a record of the domain with its record ID.
+type alidnsRecordBatchCurrentRecord struct {
+	*alidnsRecordBatchRecord
+	recordId string
+}
+
+// listRecords returns all the records of the domain by their keys.
+func (r *alidnsRecordBatchResource) listRecords(domainName string) (map[string]*alidnsRecordBatchCurrentRecord, error) {
+	records, err := listAlidnsDomainRecords(r.client, domainName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	current := map[string]*alidnsRecordBatchCurrentRecord{}
+	for _, record := range records {
+		currentRecord := &alidnsRecordBatchCurrentRecord{
+			alidnsRecordBatchRecord: &alidnsRecordBatchRecord{
+				RR:       types.StringValue(tea.StringValue(record.RR)),
+				Type:     types.StringValue(tea.StringValue(record.Type)),
+				Value:    types.StringValue(tea.StringValue(record.Value)),
+				TTL:      types.Int64Value(tea.Int64Value(record.TTL)),
+				Line:     types.StringValue(tea.StringValue(record.Line)),
+				Priority: types.Int64Null(),
+			},
+			recordId: tea.StringValue(record.RecordId),
+		}
+		if tea.StringValue(record.Type) == "MX" {
+			currentRecord.Priority = types.Int64Value(tea.Int64Value(record.Priority))
+		}
+		current[currentRecord.key()] = currentRecord
+	}
+	return current, nil
+}
+
+func (r *alidnsRecordBatchResource) updateRecordTtl(recordId string, record *alidnsRecordBatchRecord) error {
+	updateDomainRecord := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		updateDomainRecordRequest := &alicloudDnsClient.UpdateDomainRecordRequest{
+			RecordId: tea.String(recordId),
+			RR:       tea.String(record.RR.ValueString()),
+			Type:     tea.String(record.Type.ValueString()),
+			Value:    tea.String(record.Value.ValueString()),
+			TTL:      tea.Int64(record.ttl()),
+			Line:     tea.String(record.line()),
+		}
+		if !record.Priority.IsNull() {
+			updateDomainRecordRequest.Priority = tea.Int64(record.Priority.ValueInt64())
+		}
+
+		if _, err := r.client.UpdateDomainRecordWithOptions(updateDomainRecordRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(updateDomainRecord, reconnectBackoff)
+}
+
+func alidnsRecordBatchRecordInfos(domainName string, records []*alidnsRecordBatchRecord) []*alicloudDnsClient.OperateBatchDomainRequestDomainRecordInfo {
+	infos := []*alicloudDnsClient.OperateBatchDomainRequestDomainRecordInfo{}
+	for _, record := range records {
+		info := &alicloudDnsClient.OperateBatchDomainRequestDomainRecordInfo{
+			Domain: tea.String(domainName),
+			Rr:     tea.String(record.RR.ValueString()),
+			Type:   tea.String(record.Type.ValueString()),
+			Value:  tea.String(record.Value.ValueString()),
+			Ttl:    tea.Int32(int32(record.ttl())),
+			Line:   tea.String(record.line()),
+		}
+		if !record.Priority.IsNull() {
+			info.Priority = tea.Int32(int32(record.Priority.ValueInt64()))
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// alidnsBatchError is returned when some records of a batch task failed.
+type alidnsBatchError struct {
+	TaskId      int64
+	FailedCount int32
+	TotalCount  int32
+	Reason      string
+	Failures    []string
+}
+
+func (e *alidnsBatchError) Error() string {
+	if len(e.Failures) == 0 {
+		return fmt.Sprintf("%d of %d records failed in batch task %d: %s", e.FailedCount, e.TotalCount, e.TaskId, e.Reason)
+	}
+	return fmt.Sprintf("%d of %d records failed in batch task %d:\n%s", e.FailedCount, e.TotalCount, e.TaskId, strings.Join(e.Failures, "\n"))
+}
+
+// alidnsBatchErrorDiagnostics reports each failed record of a batch task as
+// a diagnostic, and the other errors as a single diagnostic.
+func alidnsBatchErrorDiagnostics(summary string, err error) diag.Diagnostics {
+	var batchErr *alidnsBatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Failures) == 0 {
+		return diag.Diagnostics{
+			diag.NewErrorDiagnostic(summary, err.Error()),
+		}
+	}
+
+	diags := diag.Diagnostics{}
+	for _, failure := range batchErr.Failures {
+		diags.AddError(summary, fmt.Sprintf("Batch task %d: %s", batchErr.TaskId, failure))
+	}
+	return diags
+}
+
+// operateAlidnsBatch submits the records with OperateBatchDomain in batches,
+// and waits for each batch task to finish. The failed records of the tasks
+// are returned as an alidnsBatchError.
+func operateAlidnsBatch(client *alicloudDnsClient.Client, batchType string, infos []*alicloudDnsClient.OperateBatchDomainRequestDomainRecordInfo) error {
+	for start := 0; start < len(infos); start += alidnsBatchSize {
+		end := start + alidnsBatchSize
+		if end > len(infos) {
+			end = len(infos)
+		}
+
+		taskId, err := submitAlidnsBatch(client, batchType, infos[start:end])
+		if err != nil {
+			return err
+		}
+		if err := waitAlidnsBatch(client, batchType, taskId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func submitAlidnsBatch(client *alicloudDnsClient.Client, batchType string, infos []*alicloudDnsClient.OperateBatchDomainRequestDomainRecordInfo) (taskId int64, err error) {
+	operateBatchDomain := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		operateBatchDomainRequest := &alicloudDnsClient.OperateBatchDomainRequest{
+			Type:             tea.String(batchType),
+			DomainRecordInfo: infos,
+		}
+
+		operateBatchDomainResponse, err := client.OperateBatchDomainWithOptions(operateBatchDomainRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		taskId = tea.Int64Value(operateBatchDomainResponse.Body.TaskId)
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(operateBatchDomain, reconnectBackoff)
+	return
+}
+
+// waitAlidnsBatch polls DescribeBatchResultCount until the batch task is
+// finished, and returns the failed records with their reasons as an error.
+func waitAlidnsBatch(client *alicloudDnsClient.Client, batchType string, taskId int64) error {
+	var result *alicloudDnsClient.DescribeBatchResultCountResponseBody
+	describeBatchResultCount := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		describeBatchResultCountRequest := &alicloudDnsClient.DescribeBatchResultCountRequest{
+			BatchType: tea.String(batchType),
+			TaskId:    tea.Int64(taskId),
+		}
+
+		describeBatchResultCountResponse, err := client.DescribeBatchResultCountWithOptions(describeBatchResultCountRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		result = describeBatchResultCountResponse.Body
+
+		// Status 0 means that the task is still running.
+		if tea.Int32Value(result.Status) == 0 {
+			return fmt.Errorf("batch task %d is still running", taskId)
+		}
+		return nil
+	}
+
+	pollBackoff := backoff.NewExponentialBackOff()
+	pollBackoff.MaxInterval = 10 * time.Second
+	pollBackoff.MaxElapsedTime = 10 * time.Minute
+	if err := backoff.Retry(describeBatchResultCount, pollBackoff); err != nil {
+		return err
+	}
+
+	if tea.Int32Value(result.FailedCount) == 0 {
+		return nil
+	}
+
+	batchErr := &alidnsBatchError{
+		TaskId:      taskId,
+		FailedCount: tea.Int32Value(result.FailedCount),
+		TotalCount:  tea.Int32Value(result.TotalCount),
+		Reason:      tea.StringValue(result.Reason),
+	}
+	// The reason of the task is still reported when the details of the
+	// failed records can not be described.
+	if failures, err := describeAlidnsBatchFailures(client, batchType, taskId); err == nil {
+		batchErr.Failures = failures
+	}
+	return batchErr
+}
+
+// describeAlidnsBatchFailures returns the failed records of the batch task
+// with their reasons, paging through DescribeBatchResultDetail.
+func describeAlidnsBatchFailures(client *alicloudDnsClient.Client, batchType string, taskId int64) (failures []string, err error) {
+	describeBatchResultDetail := func() error {
+		runtime := &util.RuntimeOptions{}
+		failures = []string{}
+
+		describeBatchResultDetailRequest := &alicloudDnsClient.DescribeBatchResultDetailRequest{
+			BatchType:  tea.String(batchType),
+			TaskId:     tea.Int64(taskId),
+			Status:     tea.String("FAIL"),
+			PageNumber: tea.Int32(1),
+			PageSize:   tea.Int32(100),
+		}
+
+		for {
+			describeBatchResultDetailResponse, err := client.DescribeBatchResultDetailWithOptions(describeBatchResultDetailRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+
+			details := describeBatchResultDetailResponse.Body.BatchResultDetails
+			if details == nil || len(details.BatchResultDetail) == 0 {
+				break
+			}
+			for _, detail := range details.BatchResultDetail {
+				failures = append(failures, fmt.Sprintf("%s %s %s (line %s): %s",
+					tea.StringValue(detail.Rr), tea.StringValue(detail.Type), tea.StringValue(detail.Value),
+					tea.StringValue(detail.Line), tea.StringValue(detail.Reason)))
+			}
+
+			if int64(len(failures)) >= tea.Int64Value(describeBatchResultDetailResponse.Body.TotalCount) {
+				break
+			}
+			describeBatchResultDetailRequest.PageNumber = tea.Int32(tea.Int32Value(describeBatchResultDetailRequest.PageNumber) + 1)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(describeBatchResultDetail, reconnectBackoff)
+	return
+}
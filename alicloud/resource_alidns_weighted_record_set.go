@@ -0,0 +1,687 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                   = &alidnsWeightedRecordSetResource{}
+	_ resource.ResourceWithConfigure      = &alidnsWeightedRecordSetResource{}
+	_ resource.ResourceWithImportState    = &alidnsWeightedRecordSetResource{}
+	_ resource.ResourceWithModifyPlan     = &alidnsWeightedRecordSetResource{}
+	_ resource.ResourceWithValidateConfig = &alidnsWeightedRecordSetResource{}
+)
+
+func NewAlidnsWeightedRecordSetResource() resource.Resource {
+	return &alidnsWeightedRecordSetResource{}
+}
+
+type alidnsWeightedRecordSetResource struct {
+	client *alicloudDnsClient.Client
+}
+
+type alidnsWeightedRecordSetResourceModel struct {
+	DomainName types.String `tfsdk:"domain_name"`
+	RR         types.String `tfsdk:"rr"`
+	Type       types.String `tfsdk:"type"`
+	Line       types.String `tfsdk:"line"`
+	TTL        types.Int64  `tfsdk:"ttl"`
+	Records    types.Map    `tfsdk:"records"`
+	RecordIds  types.Map    `tfsdk:"record_ids"`
+}
+
+func (r *alidnsWeightedRecordSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_weighted_record_set"
+}
+
+func (r *alidnsWeightedRecordSetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides an authoritative weighted round robin record set of a subdomain. All the records " +
+			"of the subdomain with the same type and line are managed, and the weight changes are applied as a unit.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description: "The domain name that the subdomain belongs to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rr": schema.StringAttribute{
+				Description: "The host record of the subdomain, e.g. www, or @ for the domain itself.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "The type of the records. Valid values: A, AAAA, CNAME.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("A", "AAAA", "CNAME"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"line": schema.StringAttribute{
+				Description: "The resolution line of the records. Default to default.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("default"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "The TTL of the records in seconds. Default to 600.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 86400),
+				},
+				Default: int64default.StaticInt64(600),
+			},
+			"records": schema.MapAttribute{
+				Description: "The weights of the records, keyed by the record value. Valid weights: 1 to 100. " +
+					"At least 2 records are required for weighted round robin. The records of the subdomain " +
+					"that are not in the map are deleted.",
+				Required:    true,
+				ElementType: types.Int64Type,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(2),
+					mapvalidator.ValueInt64sAre(int64validator.Between(1, 100)),
+				},
+			},
+			"record_ids": schema.MapAttribute{
+				Description: "The IDs of the records, keyed by the record value.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *alidnsWeightedRecordSetResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+func (r *alidnsWeightedRecordSetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *alidnsWeightedRecordSetResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Type.IsUnknown() || config.Type.IsNull() || config.Records.IsUnknown() || config.Records.IsNull() {
+		return
+	}
+
+	for value := range config.Records.Elements() {
+		if err := validateAlidnsRecordValue(config.Type.ValueString(), value); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("records").AtMapKey(value),
+				"[Input Error] Invalid Record Value",
+				err.Error(),
+			)
+		}
+	}
+}
+
+// ModifyPlan keeps the record IDs from the state when no record is added or
+// removed, since the record IDs only change when the record values change.
+func (r *alidnsWeightedRecordSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state *alidnsWeightedRecordSetResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Records.IsUnknown() {
+		return
+	}
+
+	planValues := []string{}
+	for value := range plan.Records.Elements() {
+		planValues = append(planValues, value)
+	}
+	stateValues := []string{}
+	for value := range state.Records.Elements() {
+		stateValues = append(stateValues, value)
+	}
+	removed, added := diffStringSlices(stateValues, planValues)
+	if len(removed) == 0 && len(added) == 0 {
+		plan.RecordIds = state.RecordIds
+
+		setPlanDiags := resp.Plan.Set(ctx, &plan)
+		resp.Diagnostics.Append(setPlanDiags...)
+	}
+}
+
+func (r *alidnsWeightedRecordSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *alidnsWeightedRecordSetResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := &alidnsWeightedRecordSetResourceModel{
+		DomainName: plan.DomainName,
+		RR:         plan.RR,
+		Type:       plan.Type,
+		Line:       plan.Line,
+		TTL:        plan.TTL,
+		Records:    types.MapNull(types.Int64Type),
+		RecordIds:  types.MapNull(types.StringType),
+	}
+
+	if err := r.applyRecordSet(ctx, state, plan); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Create Weighted Record Set",
+			err.Error(),
+		)
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsWeightedRecordSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *alidnsWeightedRecordSetResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := r.listRecords(state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read Weighted Record Set",
+			err.Error(),
+		)
+		return
+	}
+	if len(records) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	weights := map[string]int64{}
+	recordIds := map[string]string{}
+	for _, record := range records {
+		value := tea.StringValue(record.Value)
+		weights[value] = int64(tea.Int32Value(record.Weight))
+		recordIds[value] = tea.StringValue(record.RecordId)
+		state.TTL = types.Int64Value(tea.Int64Value(record.TTL))
+	}
+
+	weightsMap, diags := types.MapValueFrom(ctx, types.Int64Type, weights)
+	resp.Diagnostics.Append(diags...)
+	recordIdsMap, diags := types.MapValueFrom(ctx, types.StringType, recordIds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Records = weightsMap
+	state.RecordIds = recordIdsMap
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsWeightedRecordSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *alidnsWeightedRecordSetResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.applyRecordSet(ctx, state, plan); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update Weighted Record Set",
+			err.Error(),
+		)
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsWeightedRecordSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *alidnsWeightedRecordSetResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := r.listRecords(state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read Weighted Record Set",
+			err.Error(),
+		)
+		return
+	}
+
+	// Disable weighted round robin once before the records are deleted.
+	if len(records) > 1 {
+		if err := r.setSlbStatus(state, false); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Disable Weighted Round Robin",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	for _, record := range records {
+		if err := r.deleteRecord(tea.StringValue(record.RecordId)); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Delete Domain Record",
+				err.Error(),
+			)
+			return
+		}
+	}
+}
+
+func (r *alidnsWeightedRecordSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ":")
+	if len(parts) < 3 || len(parts) > 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"[Input Error] Invalid Import ID",
+			fmt.Sprintf("The import ID must be in the format of <domain name>:<rr>:<type>[:<line>], got %q.", req.ID),
+		)
+		return
+	}
+
+	line := "default"
+	if len(parts) == 4 {
+		line = parts[3]
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain_name"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("rr"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("line"), line)...)
+}
+
+// applyRecordSet changes the records of the subdomain from the state to the
+// plan as a unit. The new records are added and all the weights are updated
+// first, and they are rolled back if any of the steps fails, so the traffic is
+// never shifted halfway. The removed records are only deleted after the new
+// weights are in place. The state is updated to what is applied.
+func (r *alidnsWeightedRecordSetResource) applyRecordSet(ctx context.Context, state, plan *alidnsWeightedRecordSetResourceModel) error {
+	oldWeights := map[string]int64{}
+	if !state.Records.IsNull() {
+		if diags := state.Records.ElementsAs(ctx, &oldWeights, false); diags.HasError() {
+			return fmt.Errorf("failed to read the records from the state")
+		}
+	}
+	newWeights := map[string]int64{}
+	if diags := plan.Records.ElementsAs(ctx, &newWeights, false); diags.HasError() {
+		return fmt.Errorf("failed to read the records from the plan")
+	}
+
+	// The records are looked up from the subdomain instead of the state, so
+	// that the records added outside of Terraform are taken over.
+	records, err := r.listRecords(state)
+	if err != nil {
+		return fmt.Errorf("failed to list the records of the subdomain: %w", err)
+	}
+	recordIds := map[string]string{}
+	currentWeights := map[string]int64{}
+	currentTtls := map[string]int64{}
+	for _, record := range records {
+		value := tea.StringValue(record.Value)
+		recordIds[value] = tea.StringValue(record.RecordId)
+		currentWeights[value] = int64(tea.Int32Value(record.Weight))
+		currentTtls[value] = tea.Int64Value(record.TTL)
+	}
+
+	newValues := []string{}
+	for value := range newWeights {
+		newValues = append(newValues, value)
+	}
+	sort.Strings(newValues)
+
+	addedRecordIds := []string{}
+	updatedWeights := map[string]int64{}
+	rollback := func(cause error) error {
+		for recordId, weight := range updatedWeights {
+			if err := r.updateWeight(recordId, weight); err != nil {
+				return fmt.Errorf("%w, and failed to restore the weight of record %s: %v", cause, recordId, err)
+			}
+		}
+		for _, recordId := range addedRecordIds {
+			if err := r.deleteRecord(recordId); err != nil {
+				return fmt.Errorf("%w, and failed to delete the added record %s: %v", cause, recordId, err)
+			}
+		}
+		return cause
+	}
+
+	for _, value := range newValues {
+		if _, ok := recordIds[value]; ok {
+			continue
+		}
+		recordId, err := r.addRecord(plan, value)
+		if err != nil {
+			return rollback(fmt.Errorf("failed to add record %s: %w", value, err))
+		}
+		recordIds[value] = recordId
+		addedRecordIds = append(addedRecordIds, recordId)
+	}
+
+	// The TTL is compared with the current records, as the records taken
+	// over may have a different TTL. The added records have the planned TTL.
+	for _, value := range newValues {
+		currentTtl, existed := currentTtls[value]
+		if !existed || currentTtl == plan.TTL.ValueInt64() {
+			continue
+		}
+		if err := r.updateRecordTtl(plan, recordIds[value], value); err != nil {
+			return rollback(fmt.Errorf("failed to update the TTL of record %s: %w", value, err))
+		}
+	}
+
+	// Enable weighted round robin once for the whole subdomain.
+	slbOpen, err := r.getSlbStatus(state)
+	if err != nil {
+		return rollback(fmt.Errorf("failed to read the weighted round robin status: %w", err))
+	}
+	if !slbOpen {
+		if err := r.setSlbStatus(plan, true); err != nil {
+			return rollback(fmt.Errorf("failed to enable weighted round robin: %w", err))
+		}
+	}
+
+	for _, value := range newValues {
+		recordId := recordIds[value]
+		currentWeight, existed := currentWeights[value]
+		if existed && currentWeight == newWeights[value] {
+			continue
+		}
+		if err := r.updateWeight(recordId, newWeights[value]); err != nil {
+			return rollback(fmt.Errorf("failed to update the weight of record %s: %w", value, err))
+		}
+		if existed {
+			updatedWeights[recordId] = currentWeight
+		}
+	}
+
+	state.TTL = plan.TTL
+	appliedWeights := map[string]int64{}
+	appliedRecordIds := map[string]string{}
+	for value, weight := range newWeights {
+		appliedWeights[value] = weight
+		appliedRecordIds[value] = recordIds[value]
+	}
+
+	var deleteErr error
+	for value, recordId := range recordIds {
+		if _, ok := newWeights[value]; ok {
+			continue
+		}
+		if err := r.deleteRecord(recordId); err != nil {
+			// Keep the record in the state, so that it is deleted in the
+			// next apply.
+			appliedWeights[value] = currentWeights[value]
+			appliedRecordIds[value] = recordId
+			deleteErr = fmt.Errorf("failed to delete record %s: %w", value, err)
+		}
+	}
+
+	weightsMap, diags := types.MapValueFrom(ctx, types.Int64Type, appliedWeights)
+	if diags.HasError() {
+		return fmt.Errorf("failed to set the records to the state")
+	}
+	recordIdsMap, diags := types.MapValueFrom(ctx, types.StringType, appliedRecordIds)
+	if diags.HasError() {
+		return fmt.Errorf("failed to set the record IDs to the state")
+	}
+	state.Records = weightsMap
+	state.RecordIds = recordIdsMap
+
+	return deleteErr
+}
+
+// listRecords returns the records of the subdomain with the type and line of
+// the record set.
+func (r *alidnsWeightedRecordSetResource) listRecords(model *alidnsWeightedRecordSetResourceModel) ([]*alicloudDnsClient.DescribeSubDomainRecordsResponseBodyDomainRecordsRecord, error) {
+	records, err := listAlidnsSubDomainRecords(r.client, model.DomainName.ValueString(), model.RR.ValueString(), model.Type.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := []*alicloudDnsClient.DescribeSubDomainRecordsResponseBodyDomainRecordsRecord{}
+	for _, record := range records {
+		if tea.StringValue(record.Line) == model.Line.ValueString() {
+			filtered = append(filtered, record)
+		}
+	}
+	return filtered, nil
+}
+
+func (r *alidnsWeightedRecordSetResource) addRecord(plan *alidnsWeightedRecordSetResourceModel, value string) (recordId string, err error) {
+	addDomainRecord := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		addDomainRecordRequest := &alicloudDnsClient.AddDomainRecordRequest{
+			DomainName: tea.String(plan.DomainName.ValueString()),
+			RR:         tea.String(plan.RR.ValueString()),
+			Type:       tea.String(plan.Type.ValueString()),
+			Value:      tea.String(value),
+			TTL:        tea.Int64(plan.TTL.ValueInt64()),
+			Line:       tea.String(plan.Line.ValueString()),
+		}
+
+		addDomainRecordResponse, err := r.client.AddDomainRecordWithOptions(addDomainRecordRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		recordId = tea.StringValue(addDomainRecordResponse.Body.RecordId)
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(addDomainRecord, reconnectBackoff)
+	return
+}
+
+func (r *alidnsWeightedRecordSetResource) updateRecordTtl(plan *alidnsWeightedRecordSetResourceModel, recordId, value string) error {
+	updateDomainRecord := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		updateDomainRecordRequest := &alicloudDnsClient.UpdateDomainRecordRequest{
+			RecordId: tea.String(recordId),
+			RR:       tea.String(plan.RR.ValueString()),
+			Type:     tea.String(plan.Type.ValueString()),
+			Value:    tea.String(value),
+			TTL:      tea.Int64(plan.TTL.ValueInt64()),
+			Line:     tea.String(plan.Line.ValueString()),
+		}
+
+		if _, err := r.client.UpdateDomainRecordWithOptions(updateDomainRecordRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(updateDomainRecord, reconnectBackoff)
+}
+
+func (r *alidnsWeightedRecordSetResource) updateWeight(recordId string, weight int64) error {
+	updateDNSSLBWeight := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		updateDNSSLBWeightRequest := &alicloudDnsClient.UpdateDNSSLBWeightRequest{
+			RecordId: tea.String(recordId),
+			Weight:   tea.Int32(int32(weight)),
+		}
+
+		if _, err := r.client.UpdateDNSSLBWeightWithOptions(updateDNSSLBWeightRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(updateDNSSLBWeight, reconnectBackoff)
+}
+
+// deleteRecord deletes the record, the record that is already deleted is ignored.
+func (r *alidnsWeightedRecordSetResource) deleteRecord(recordId string) error {
+	deleteDomainRecord := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		deleteDomainRecordRequest := &alicloudDnsClient.DeleteDomainRecordRequest{
+			RecordId: tea.String(recordId),
+		}
+
+		if _, err := r.client.DeleteDomainRecordWithOptions(deleteDomainRecordRequest, runtime); err != nil {
+			if isAlidnsRecordNotFound(err) {
+				return nil
+			}
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(deleteDomainRecord, reconnectBackoff)
+}
+
+// getSlbStatus returns whether weighted round robin is enabled for the
+// subdomain, paging through DescribeDNSSLBSubDomains.
+func (r *alidnsWeightedRecordSetResource) getSlbStatus(model *alidnsWeightedRecordSetResourceModel) (open bool, err error) {
+	subDomain := alidnsSubDomainName(model.DomainName.ValueString(), model.RR.ValueString())
+
+	describeDNSSLBSubDomains := func() error {
+		runtime := &util.RuntimeOptions{}
+		open = false
+
+		describeDNSSLBSubDomainsRequest := &alicloudDnsClient.DescribeDNSSLBSubDomainsRequest{
+			DomainName: tea.String(model.DomainName.ValueString()),
+			Rr:         tea.String(model.RR.ValueString()),
+			PageNumber: tea.Int64(1),
+			PageSize:   tea.Int64(100),
+		}
+
+		count := 0
+		for {
+			describeDNSSLBSubDomainsResponse, err := r.client.DescribeDNSSLBSubDomainsWithOptions(describeDNSSLBSubDomainsRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+
+			slbSubDomains := describeDNSSLBSubDomainsResponse.Body.SlbSubDomains
+			if slbSubDomains == nil || len(slbSubDomains.SlbSubDomain) == 0 {
+				break
+			}
+			for _, slbSubDomain := range slbSubDomains.SlbSubDomain {
+				if tea.StringValue(slbSubDomain.SubDomain) == subDomain && tea.StringValue(slbSubDomain.Type) == model.Type.ValueString() {
+					open = tea.BoolValue(slbSubDomain.Open)
+					return nil
+				}
+			}
+
+			count += len(slbSubDomains.SlbSubDomain)
+			if int64(count) >= tea.Int64Value(describeDNSSLBSubDomainsResponse.Body.TotalCount) {
+				break
+			}
+			describeDNSSLBSubDomainsRequest.PageNumber = tea.Int64(tea.Int64Value(describeDNSSLBSubDomainsRequest.PageNumber) + 1)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(describeDNSSLBSubDomains, reconnectBackoff)
+	return
+}
+
+func (r *alidnsWeightedRecordSetResource) setSlbStatus(model *alidnsWeightedRecordSetResourceModel, open bool) error {
+	setDNSSLBStatus := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		setDNSSLBStatusRequest := &alicloudDnsClient.SetDNSSLBStatusRequest{
+			DomainName: tea.String(model.DomainName.ValueString()),
+			SubDomain:  tea.String(alidnsSubDomainName(model.DomainName.ValueString(), model.RR.ValueString())),
+			Type:       tea.String(model.Type.ValueString()),
+			Line:       tea.String(model.Line.ValueString()),
+			Open:       tea.Bool(open),
+		}
+
+		if _, err := r.client.SetDNSSLBStatusWithOptions(setDNSSLBStatusRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(setDNSSLBStatus, reconnectBackoff)
+}
+
+// alidnsSubDomainName returns the full name of the subdomain of the host
+// record, the host record @ is the domain itself.
+func alidnsSubDomainName(domainName, rr string) string {
+	if rr == "@" {
+		return domainName
+	}
+	return rr + "." + domainName
+}
@@ -0,0 +1,876 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                   = &alidnsZoneFileResource{}
+	_ resource.ResourceWithConfigure      = &alidnsZoneFileResource{}
+	_ resource.ResourceWithImportState    = &alidnsZoneFileResource{}
+	_ resource.ResourceWithValidateConfig = &alidnsZoneFileResource{}
+)
+
+// The record types that can be managed with a zone file, the records of the
+// other types, e.g. the URL forwarding records, are left untouched.
+func getAlidnsZoneFileRecordTypes() []string {
+	return []string{"A", "AAAA", "CNAME", "MX", "NS", "TXT", "SRV", "CAA"}
+}
+
+const (
+	alidnsZoneFileDefaultTTL  = 600
+	alidnsZoneFileDefaultLine = "default"
+)
+
+func NewAlidnsZoneFileResource() resource.Resource {
+	return &alidnsZoneFileResource{}
+}
+
+type alidnsZoneFileResource struct {
+	client *alicloudDnsClient.Client
+}
+
+type alidnsZoneFileResourceModel struct {
+	DomainName  types.String `tfsdk:"domain_name"`
+	Content     types.String `tfsdk:"content"`
+	RecordCount types.Int64  `tfsdk:"record_count"`
+}
+
+// alidnsZoneRecord is a record of a zone file in the form stored by Alidns.
+// The host record is relative to the domain and the targets of the records
+// have no trailing dot.
+type alidnsZoneRecord struct {
+	RR       string
+	Type     string
+	Value    string
+	TTL      int64
+	Priority int64
+	RecordId string
+}
+
+func (r *alidnsZoneRecord) key() string {
+	return strings.ToLower(r.RR) + " " + r.Type + " " + r.Value
+}
+
+func (r *alidnsZoneFileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_zone_file"
+}
+
+func (r *alidnsZoneFileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the records of an Alidns domain from an RFC 1035 zone file. The zone file is " +
+			"authoritative for the records of the default line with the types A, AAAA, CNAME, MX, NS, TXT, SRV " +
+			"and CAA, and the other records of these types are deleted. The changes are applied in batches.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description: "The domain name of the zone.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				Description: "The zone file text. $ORIGIN and $TTL are supported, the origin defaults to the domain name " +
+					"and the TTL defaults to 600. The SOA record and the NS records of the domain itself are ignored, " +
+					"since they are managed by Alidns.",
+				Required: true,
+			},
+			"record_count": schema.Int64Attribute{
+				Description: "The number of the records in the zone file.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *alidnsZoneFileResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+func (r *alidnsZoneFileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *alidnsZoneFileResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.DomainName.IsUnknown() || config.DomainName.IsNull() || config.Content.IsUnknown() || config.Content.IsNull() {
+		return
+	}
+
+	if _, err := parseAlidnsZoneFile(config.DomainName.ValueString(), config.Content.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("content"),
+			"[Input Error] Invalid Zone File",
+			err.Error(),
+		)
+	}
+}
+
+func (r *alidnsZoneFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *alidnsZoneFileResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := parseAlidnsZoneFile(plan.DomainName.ValueString(), plan.Content.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("content"),
+			"[Input Error] Invalid Zone File",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := r.applyZone(plan.DomainName.ValueString(), records); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Apply Zone File",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &alidnsZoneFileResourceModel{
+		DomainName:  plan.DomainName,
+		Content:     plan.Content,
+		RecordCount: types.Int64Value(int64(len(records))),
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsZoneFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *alidnsZoneFileResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := listAlidnsZoneRecords(r.client, state.DomainName.ValueString())
+	if err != nil {
+		if isAlidnsDomainNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Domain Records",
+			err.Error(),
+		)
+		return
+	}
+
+	// Keep the configured zone file when it matches the records, so that the
+	// formatting of the file does not cause a diff. Otherwise the records are
+	// rendered as a zone file to show the drift.
+	if !state.Content.IsNull() {
+		if records, err := parseAlidnsZoneFile(state.DomainName.ValueString(), state.Content.ValueString()); err == nil {
+			toAdd, toUpdate, toDelete := diffAlidnsZoneRecords(current, records)
+			if len(toAdd) == 0 && len(toUpdate) == 0 && len(toDelete) == 0 {
+				state.RecordCount = types.Int64Value(int64(len(records)))
+
+				setStateDiags := resp.State.Set(ctx, &state)
+				resp.Diagnostics.Append(setStateDiags...)
+				return
+			}
+		}
+	}
+
+	state.Content = types.StringValue(renderAlidnsZoneFile(state.DomainName.ValueString(), current))
+	state.RecordCount = types.Int64Value(int64(len(current)))
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsZoneFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *alidnsZoneFileResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := parseAlidnsZoneFile(plan.DomainName.ValueString(), plan.Content.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("content"),
+			"[Input Error] Invalid Zone File",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := r.applyZone(plan.DomainName.ValueString(), records); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Apply Zone File",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &alidnsZoneFileResourceModel{
+		DomainName:  plan.DomainName,
+		Content:     plan.Content,
+		RecordCount: types.Int64Value(int64(len(records))),
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *alidnsZoneFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *alidnsZoneFileResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := parseAlidnsZoneFile(state.DomainName.ValueString(), state.Content.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[Input Error] Invalid Zone File",
+			err.Error(),
+		)
+		return
+	}
+
+	// Only the records in the zone file are deleted.
+	current, err := listAlidnsZoneRecords(r.client, state.DomainName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Domain Records",
+			err.Error(),
+		)
+		return
+	}
+	managed := map[string]bool{}
+	for _, record := range records {
+		managed[record.key()] = true
+	}
+	toDelete := []*alidnsZoneRecord{}
+	for _, record := range current {
+		if managed[record.key()] {
+			toDelete = append(toDelete, record)
+		}
+	}
+
+	if err := operateAlidnsBatch(r.client, "RR_DEL", alidnsZoneBatchRecordInfos(state.DomainName.ValueString(), toDelete)); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Delete Domain Records",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *alidnsZoneFileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("domain_name"), req, resp)
+}
+
+// applyZone makes the records of the domain match the records of the zone
+// file. The records are added before the old records are deleted, so that
+// the names keep resolving and no record is lost if the records fail to be
+// added. Only the old records conflicting with the added records, e.g. an A
+// record replaced by a CNAME record, are deleted first.
+func (r *alidnsZoneFileResource) applyZone(domainName string, records []*alidnsZoneRecord) error {
+	current, err := listAlidnsZoneRecords(r.client, domainName)
+	if err != nil {
+		return fmt.Errorf("failed to describe domain records: %w", err)
+	}
+
+	toAdd, toUpdate, toDelete := diffAlidnsZoneRecords(current, records)
+
+	conflicting, remaining := []*alidnsZoneRecord{}, []*alidnsZoneRecord{}
+	for _, record := range toDelete {
+		conflicted := false
+		for _, added := range toAdd {
+			if isAlidnsRecordConflict(record.RR, record.Type, added.RR, added.Type) {
+				conflicted = true
+				break
+			}
+		}
+		if conflicted {
+			conflicting = append(conflicting, record)
+		} else {
+			remaining = append(remaining, record)
+		}
+	}
+
+	if err := operateAlidnsBatch(r.client, "RR_DEL", alidnsZoneBatchRecordInfos(domainName, conflicting)); err != nil {
+		return fmt.Errorf("failed to delete conflicting records: %w", err)
+	}
+
+	for _, record := range toUpdate {
+		updateDomainRecord := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			updateDomainRecordRequest := &alicloudDnsClient.UpdateDomainRecordRequest{
+				RecordId: tea.String(record.RecordId),
+				RR:       tea.String(record.RR),
+				Type:     tea.String(record.Type),
+				Value:    tea.String(record.Value),
+				TTL:      tea.Int64(record.TTL),
+				Line:     tea.String(alidnsZoneFileDefaultLine),
+			}
+			if record.Type == "MX" {
+				updateDomainRecordRequest.Priority = tea.Int64(record.Priority)
+			}
+
+			if _, err := r.client.UpdateDomainRecordWithOptions(updateDomainRecordRequest, runtime); err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(updateDomainRecord, reconnectBackoff); err != nil {
+			return fmt.Errorf("failed to update record %s: %w", record.key(), err)
+		}
+	}
+
+	if err := operateAlidnsBatch(r.client, "RR_ADD", alidnsZoneBatchRecordInfos(domainName, toAdd)); err != nil {
+		return fmt.Errorf("failed to add records: %w", err)
+	}
+
+	if err := operateAlidnsBatch(r.client, "RR_DEL", alidnsZoneBatchRecordInfos(domainName, remaining)); err != nil {
+		return fmt.Errorf("failed to delete records: %w", err)
+	}
+	return nil
+}
+
+// isAlidnsRecordConflict returns whether the two records of the same line can
+// not coexist, i.e. a CNAME record and any other record of the same host
+// record.
+func isAlidnsRecordConflict(rr, recordType, otherRR, otherType string) bool {
+	return strings.EqualFold(rr, otherRR) && (recordType == "CNAME" || otherType == "CNAME")
+}
+
+// listAlidnsZoneRecords returns the records of the domain that can be managed
+// with a zone file.
+func listAlidnsZoneRecords(client *alicloudDnsClient.Client, domainName string) ([]*alidnsZoneRecord, error) {
+	records, err := listAlidnsDomainRecords(client, domainName, map[string]*string{
+		"Line": tea.String(alidnsZoneFileDefaultLine),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	zoneRecordTypes := map[string]bool{}
+	for _, recordType := range getAlidnsZoneFileRecordTypes() {
+		zoneRecordTypes[recordType] = true
+	}
+
+	zoneRecords := []*alidnsZoneRecord{}
+	for _, record := range records {
+		recordType := tea.StringValue(record.Type)
+		if !zoneRecordTypes[recordType] || tea.StringValue(record.Line) != alidnsZoneFileDefaultLine {
+			continue
+		}
+		if recordType == "NS" && tea.StringValue(record.RR) == "@" {
+			continue
+		}
+		zoneRecord := &alidnsZoneRecord{
+			RR:       tea.StringValue(record.RR),
+			Type:     recordType,
+			Value:    tea.StringValue(record.Value),
+			TTL:      tea.Int64Value(record.TTL),
+			RecordId: tea.StringValue(record.RecordId),
+		}
+		if recordType == "MX" {
+			zoneRecord.Priority = tea.Int64Value(record.Priority)
+		}
+		zoneRecords = append(zoneRecords, zoneRecord)
+	}
+	return zoneRecords, nil
+}
+
+// diffAlidnsZoneRecords compares the current records with the desired records.
+// The records are matched by host record, type and value, and the matched
+// records with a different TTL or priority are updated in place.
+func diffAlidnsZoneRecords(current, desired []*alidnsZoneRecord) (toAdd, toUpdate, toDelete []*alidnsZoneRecord) {
+	currentRecords := map[string]*alidnsZoneRecord{}
+	for _, record := range current {
+		currentRecords[record.key()] = record
+	}
+	desiredRecords := map[string]bool{}
+
+	for _, record := range desired {
+		desiredRecords[record.key()] = true
+		currentRecord, ok := currentRecords[record.key()]
+		if !ok {
+			toAdd = append(toAdd, record)
+			continue
+		}
+		if currentRecord.TTL != record.TTL || currentRecord.Priority != record.Priority {
+			updated := *record
+			updated.RecordId = currentRecord.RecordId
+			toUpdate = append(toUpdate, &updated)
+		}
+	}
+
+	for _, record := range current {
+		if !desiredRecords[record.key()] {
+			toDelete = append(toDelete, record)
+		}
+	}
+	return
+}
+
+func alidnsZoneBatchRecordInfos(domainName string, records []*alidnsZoneRecord) []*alicloudDnsClient.OperateBatchDomainRequestDomainRecordInfo {
+	infos := []*alicloudDnsClient.OperateBatchDomainRequestDomainRecordInfo{}
+	for _, record := range records {
+		info := &alicloudDnsClient.OperateBatchDomainRequestDomainRecordInfo{
+			Domain: tea.String(domainName),
+			Rr:     tea.String(record.RR),
+			Type:   tea.String(record.Type),
+			Value:  tea.String(record.Value),
+			Ttl:    tea.Int32(int32(record.TTL)),
+			Line:   tea.String(alidnsZoneFileDefaultLine),
+		}
+		if record.Type == "MX" {
+			info.Priority = tea.Int32(int32(record.Priority))
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// alidnsZoneToken is a token of a zone file, the quoted strings are kept with
+// their quotes and escapes.
+type alidnsZoneToken struct {
+	text   string
+	quoted bool
+}
+
+// alidnsZoneEntry is a logical line of a zone file, with the lines inside
+// parentheses joined.
+type alidnsZoneEntry struct {
+	line         int
+	ownerOmitted bool
+	tokens       []alidnsZoneToken
+}
+
+// tokenizeAlidnsZoneFile splits the zone file into entries, removing the
+// comments and joining the lines inside parentheses.
+func tokenizeAlidnsZoneFile(content string) ([]*alidnsZoneEntry, error) {
+	entries := []*alidnsZoneEntry{}
+	var entry *alidnsZoneEntry
+	line, depth := 1, 0
+	runes := []rune(content)
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+
+		if entry == nil {
+			entry = &alidnsZoneEntry{line: line, ownerOmitted: c == ' ' || c == '\t'}
+		}
+
+		switch {
+		case c == '\n':
+			line++
+			if depth == 0 {
+				if len(entry.tokens) > 0 {
+					entries = append(entries, entry)
+				}
+				entry = nil
+			}
+		case c == ';':
+			for i+1 < len(runes) && runes[i+1] != '\n' {
+				i++
+			}
+		case c == '(':
+			depth++
+		case c == ')':
+			if depth == 0 {
+				return nil, fmt.Errorf("line %d: unbalanced parentheses", line)
+			}
+			depth--
+		case c == '"':
+			start := i
+			for i++; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' {
+					i++
+				} else if runes[i] == '\n' {
+					return nil, fmt.Errorf("line %d: unterminated quoted string", line)
+				}
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("line %d: unterminated quoted string", line)
+			}
+			entry.tokens = append(entry.tokens, alidnsZoneToken{text: string(runes[start : i+1]), quoted: true})
+		case unicode.IsSpace(c):
+		default:
+			start := i
+			for i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) && !strings.ContainsRune(";()\"", runes[i+1]) {
+				i++
+			}
+			entry.tokens = append(entry.tokens, alidnsZoneToken{text: string(runes[start : i+1])})
+		}
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", line)
+	}
+	if entry != nil && len(entry.tokens) > 0 {
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// parseAlidnsZoneFile parses the zone file of the domain into the records in
+// the form stored by Alidns.
+func parseAlidnsZoneFile(domainName, content string) ([]*alidnsZoneRecord, error) {
+	entries, err := tokenizeAlidnsZoneFile(content)
+	if err != nil {
+		return nil, err
+	}
+
+	domain := strings.ToLower(strings.TrimSuffix(domainName, ".")) + "."
+	origin := domain
+	defaultTTL := int64(alidnsZoneFileDefaultTTL)
+	owner := ""
+
+	zoneRecordTypes := map[string]bool{}
+	for _, recordType := range getAlidnsZoneFileRecordTypes() {
+		zoneRecordTypes[recordType] = true
+	}
+
+	// absolute returns the fully qualified name of the name relative to the
+	// origin.
+	absolute := func(name string) string {
+		switch {
+		case name == "@":
+			return origin
+		case strings.HasSuffix(name, "."):
+			return name
+		default:
+			return name + "." + origin
+		}
+	}
+	// target returns the name in the form stored by Alidns, without the
+	// trailing dot.
+	target := func(name string) string {
+		return strings.TrimSuffix(absolute(name), ".")
+	}
+
+	records := []*alidnsZoneRecord{}
+	seen := map[string]*alidnsZoneRecord{}
+
+	for _, entry := range entries {
+		tokens := entry.tokens
+
+		if strings.HasPrefix(tokens[0].text, "$") {
+			switch strings.ToUpper(tokens[0].text) {
+			case "$ORIGIN":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $ORIGIN requires one domain name", entry.line)
+				}
+				origin = strings.ToLower(absolute(tokens[1].text))
+			case "$TTL":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $TTL requires one TTL", entry.line)
+				}
+				ttl, err := parseAlidnsZoneTTL(tokens[1].text)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", entry.line, err)
+				}
+				defaultTTL = ttl
+			default:
+				return nil, fmt.Errorf("line %d: unsupported directive %s", entry.line, tokens[0].text)
+			}
+			continue
+		}
+
+		if !entry.ownerOmitted {
+			owner = strings.ToLower(absolute(tokens[0].text))
+			tokens = tokens[1:]
+		}
+		if owner == "" {
+			return nil, fmt.Errorf("line %d: the owner name of the first record can not be omitted", entry.line)
+		}
+
+		ttl := defaultTTL
+		for len(tokens) > 0 {
+			if class := strings.ToUpper(tokens[0].text); class == "IN" {
+				tokens = tokens[1:]
+				continue
+			} else if class == "CH" || class == "HS" {
+				return nil, fmt.Errorf("line %d: unsupported class %s", entry.line, class)
+			}
+			if n, err := parseAlidnsZoneTTL(tokens[0].text); err == nil {
+				ttl = n
+				tokens = tokens[1:]
+				continue
+			}
+			break
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("line %d: missing record type", entry.line)
+		}
+
+		recordType := strings.ToUpper(tokens[0].text)
+		rdata := tokens[1:]
+
+		var rr string
+		switch {
+		case owner == domain:
+			rr = "@"
+		case strings.HasSuffix(owner, "."+domain):
+			rr = strings.TrimSuffix(owner, "."+domain)
+		default:
+			return nil, fmt.Errorf("line %d: %s is not in the domain %s", entry.line, owner, domainName)
+		}
+
+		// The SOA record and the NS records of the domain itself are managed
+		// by Alidns.
+		if recordType == "SOA" || (recordType == "NS" && rr == "@") {
+			continue
+		}
+		if !zoneRecordTypes[recordType] {
+			return nil, fmt.Errorf("line %d: unsupported record type %s", entry.line, recordType)
+		}
+
+		record := &alidnsZoneRecord{
+			RR:   rr,
+			Type: recordType,
+			TTL:  ttl,
+		}
+
+		expectFields := func(n int) error {
+			if len(rdata) != n {
+				return fmt.Errorf("line %d: %s record requires %d fields, got %d", entry.line, recordType, n, len(rdata))
+			}
+			return nil
+		}
+
+		switch recordType {
+		case "A", "AAAA":
+			if err := expectFields(1); err != nil {
+				return nil, err
+			}
+			record.Value = rdata[0].text
+		case "CNAME", "NS":
+			if err := expectFields(1); err != nil {
+				return nil, err
+			}
+			record.Value = target(rdata[0].text)
+		case "MX":
+			if err := expectFields(2); err != nil {
+				return nil, err
+			}
+			priority, err := strconv.ParseInt(rdata[0].text, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid MX preference %s", entry.line, rdata[0].text)
+			}
+			record.Priority = priority
+			record.Value = target(rdata[1].text)
+		case "TXT":
+			if len(rdata) == 0 {
+				return nil, fmt.Errorf("line %d: TXT record requires at least one string", entry.line)
+			}
+			var value strings.Builder
+			for _, token := range rdata {
+				value.WriteString(unquoteAlidnsZoneString(token))
+			}
+			record.Value = value.String()
+		case "SRV":
+			if err := expectFields(4); err != nil {
+				return nil, err
+			}
+			record.Value = fmt.Sprintf("%s %s %s %s", rdata[0].text, rdata[1].text, rdata[2].text, target(rdata[3].text))
+		case "CAA":
+			if err := expectFields(3); err != nil {
+				return nil, err
+			}
+			record.Value = fmt.Sprintf("%s %s %q", rdata[0].text, strings.ToLower(rdata[1].text), unquoteAlidnsZoneString(rdata[2]))
+		}
+
+		if recordType != "NS" {
+			if err := validateAlidnsRecordValue(recordType, record.Value); err != nil {
+				return nil, fmt.Errorf("line %d: %w", entry.line, err)
+			}
+		}
+
+		if existing, ok := seen[record.key()]; ok {
+			if existing.TTL != record.TTL || existing.Priority != record.Priority {
+				return nil, fmt.Errorf("line %d: duplicate record %s with a different TTL or priority", entry.line, record.key())
+			}
+			continue
+		}
+		seen[record.key()] = record
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// parseAlidnsZoneTTL parses a TTL in seconds, or with the BIND units, e.g. 1h30m.
+func parseAlidnsZoneTTL(text string) (int64, error) {
+	if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return n, nil
+	}
+
+	units := map[rune]int64{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	var ttl, n int64
+	digits := false
+	for _, c := range strings.ToLower(text) {
+		switch {
+		case c >= '0' && c <= '9':
+			n = n*10 + int64(c-'0')
+			digits = true
+		case units[c] > 0 && digits:
+			ttl += n * units[c]
+			n, digits = 0, false
+		default:
+			return 0, fmt.Errorf("invalid TTL %s", text)
+		}
+	}
+	if digits {
+		return 0, fmt.Errorf("invalid TTL %s", text)
+	}
+	return ttl, nil
+}
+
+func unquoteAlidnsZoneString(token alidnsZoneToken) string {
+	if !token.quoted {
+		return token.text
+	}
+
+	text := token.text[1 : len(token.text)-1]
+	var unquoted strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\\' && i+1 < len(text) {
+			i++
+			// \DDD is a character in decimal.
+			if i+2 < len(text) && isAsciiDigit(text[i]) && isAsciiDigit(text[i+1]) && isAsciiDigit(text[i+2]) {
+				n, _ := strconv.Atoi(text[i : i+3])
+				unquoted.WriteByte(byte(n))
+				i += 2
+				continue
+			}
+		}
+		unquoted.WriteByte(text[i])
+	}
+	return unquoted.String()
+}
+
+func isAsciiDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// renderAlidnsZoneFile renders the records of the domain as a zone file,
+// sorted by host record, type and value.
+func renderAlidnsZoneFile(domainName string, records []*alidnsZoneRecord) string {
+	sorted := append([]*alidnsZoneRecord{}, records...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].RR != sorted[j].RR {
+			if sorted[i].RR == "@" || sorted[j].RR == "@" {
+				return sorted[i].RR == "@"
+			}
+			return sorted[i].RR < sorted[j].RR
+		}
+		if sorted[i].Type != sorted[j].Type {
+			return sorted[i].Type < sorted[j].Type
+		}
+		return sorted[i].Value < sorted[j].Value
+	})
+
+	// absolute returns the name with the trailing dot, so that it is not
+	// relative to the origin.
+	absolute := func(name string) string {
+		if strings.HasSuffix(name, ".") {
+			return name
+		}
+		return name + "."
+	}
+
+	var zone strings.Builder
+	fmt.Fprintf(&zone, "$ORIGIN %s.\n", strings.TrimSuffix(domainName, "."))
+	fmt.Fprintf(&zone, "$TTL %d\n", alidnsZoneFileDefaultTTL)
+
+	for _, record := range sorted {
+		var rdata string
+		switch record.Type {
+		case "CNAME", "NS":
+			rdata = absolute(record.Value)
+		case "MX":
+			rdata = fmt.Sprintf("%d %s", record.Priority, absolute(record.Value))
+		case "SRV":
+			fields := strings.Fields(record.Value)
+			if len(fields) == 4 {
+				fields[3] = absolute(fields[3])
+			}
+			rdata = strings.Join(fields, " ")
+		case "TXT":
+			// The strings of a TXT record are at most 255 characters.
+			chunks := []string{}
+			value := record.Value
+			for len(value) > 255 {
+				chunks = append(chunks, quoteAlidnsZoneString(value[:255]))
+				value = value[255:]
+			}
+			chunks = append(chunks, quoteAlidnsZoneString(value))
+			rdata = strings.Join(chunks, " ")
+		default:
+			rdata = record.Value
+		}
+		fmt.Fprintf(&zone, "%s\t%d\tIN\t%s\t%s\n", record.RR, record.TTL, record.Type, rdata)
+	}
+	return zone.String()
+}
+
+func quoteAlidnsZoneString(text string) string {
+	var quoted strings.Builder
+	quoted.WriteByte('"')
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '"' || c == '\\':
+			quoted.WriteByte('\\')
+			quoted.WriteByte(c)
+		case c < 0x20 || c > 0x7e:
+			fmt.Fprintf(&quoted, "\\%03d", c)
+		default:
+			quoted.WriteByte(c)
+		}
+	}
+	quoted.WriteByte('"')
+	return quoted.String()
+}
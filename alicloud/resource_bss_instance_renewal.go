@@ -0,0 +1,397 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudBaseClient "github.com/alibabacloud-go/bssopenapi-20171214/v3/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                   = &bssInstanceRenewalResource{}
+	_ resource.ResourceWithConfigure      = &bssInstanceRenewalResource{}
+	_ resource.ResourceWithValidateConfig = &bssInstanceRenewalResource{}
+	_ resource.ResourceWithModifyPlan     = &bssInstanceRenewalResource{}
+	_ resource.ResourceWithImportState    = &bssInstanceRenewalResource{}
+)
+
+func NewBssInstanceRenewalResource() resource.Resource {
+	return &bssInstanceRenewalResource{}
+}
+
+type bssInstanceRenewalResource struct {
+	client *alicloudBaseClient.Client
+}
+
+type bssInstanceRenewalResourceModel struct {
+	ProductCode   types.String `tfsdk:"product_code"`
+	ProductType   types.String `tfsdk:"product_type"`
+	InstanceId    types.String `tfsdk:"instance_id"`
+	RenewalStatus types.String `tfsdk:"renewal_status"`
+	RenewPeriod   types.Int64  `tfsdk:"renew_period"`
+	Status        types.String `tfsdk:"status"`
+	EndTime       types.String `tfsdk:"end_time"`
+}
+
+func (r *bssInstanceRenewalResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bss_instance_renewal"
+}
+
+func (r *bssInstanceRenewalResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a resource to manage the renewal of a subscription instance of any AliCloud product, " +
+			"e.g. Anti-DDoS, ADB or DNS. Destroying the resource leaves the renewal of the instance unchanged.",
+		Attributes: map[string]schema.Attribute{
+			"product_code": schema.StringAttribute{
+				Description: "The code of the product, e.g. ddoscoo, ads or dns.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"product_type": schema.StringAttribute{
+				Description: "The type of the product, required by the products with more than one type, " +
+					"e.g. ddoscoo_intl or dns_dns_public_intl.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"instance_id": schema.StringAttribute{
+				Description: "The ID of the subscription instance.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"renewal_status": schema.StringAttribute{
+				Description: "The renewal status of the instance. Valid values: AutoRenewal, ManualRenewal, NotRenewal.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("AutoRenewal", "ManualRenewal", "NotRenewal"),
+				},
+			},
+			"renew_period": schema.Int64Attribute{
+				Description: "The automatic renewal period, the unit is month. It must be set when renewal_status is AutoRenewal.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "The status of the instance.",
+				Computed:    true,
+			},
+			"end_time": schema.StringAttribute{
+				Description: "The time when the instance expires.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *bssInstanceRenewalResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(alicloudClients).baseClient
+}
+
+func (r *bssInstanceRenewalResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var renewalStatus types.String
+	var renewPeriod types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("renewal_status"), &renewalStatus)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("renew_period"), &renewPeriod)...)
+	if resp.Diagnostics.HasError() || renewalStatus.IsUnknown() || renewPeriod.IsUnknown() {
+		return
+	}
+
+	if renewalStatus.ValueString() == "AutoRenewal" && renewPeriod.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("renew_period"),
+			"[Input Error] Missing Renew Period",
+			"renew_period is required when AutoRenewal is set in renewal_status.",
+		)
+	}
+	if renewalStatus.ValueString() != "AutoRenewal" && !renewPeriod.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("renew_period"),
+			"[Input Error] Unexpected Renew Period",
+			"renew_period can only be set when AutoRenewal is set in renewal_status.",
+		)
+	}
+}
+
+func (r *bssInstanceRenewalResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// If the entire plan is null, the resource is planned for destruction.
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.AddWarning(
+			"AliCloud Instance Renewal is left unchanged",
+			"Terraform will only stop managing the renewal of the instance, the "+
+				"renewal status of the instance is left as it is.",
+		)
+	}
+}
+
+func (r *bssInstanceRenewalResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *bssInstanceRenewalResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setRenewal(plan); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Set Instance Renewal",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &bssInstanceRenewalResourceModel{
+		ProductCode:   plan.ProductCode,
+		ProductType:   plan.ProductType,
+		InstanceId:    plan.InstanceId,
+		RenewalStatus: plan.RenewalStatus,
+		RenewPeriod:   plan.RenewPeriod,
+	}
+	if err := r.readInstance(state); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Query Instance Renewal",
+			err.Error(),
+		)
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *bssInstanceRenewalResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *bssInstanceRenewalResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var availableInstance *alicloudBaseClient.QueryAvailableInstancesResponseBodyDataInstanceList
+	err := onBssEndpoints(func(endpoint string) (err error) {
+		availableInstance, err = queryBssAvailableInstance(r.client, endpoint, state.ProductCode.ValueString(), state.ProductType.ValueString(), state.InstanceId.ValueString())
+		return
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Query Instance Renewal",
+			err.Error(),
+		)
+		return
+	}
+	if availableInstance == nil || tea.StringValue(availableInstance.Status) == "Released" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.RenewalStatus = types.StringValue(tea.StringValue(availableInstance.RenewStatus))
+	state.RenewPeriod = types.Int64Null()
+	if tea.StringValue(availableInstance.RenewStatus) == "AutoRenewal" {
+		state.RenewPeriod = types.Int64Value(getBssRenewPeriod(availableInstance))
+	}
+	state.Status = types.StringValue(tea.StringValue(availableInstance.Status))
+	state.EndTime = types.StringValue(tea.StringValue(availableInstance.EndTime))
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *bssInstanceRenewalResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *bssInstanceRenewalResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setRenewal(plan); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Set Instance Renewal",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := r.readInstance(plan); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Query Instance Renewal",
+			err.Error(),
+		)
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete leaves the renewal of the instance unchanged, the instance is not
+// managed by this resource.
+func (r *bssInstanceRenewalResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r *bssInstanceRenewalResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"[Input Error] Invalid Import ID",
+			fmt.Sprintf("The import ID must be in the format of <product code>:<instance id>[:<product type>], got %q.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("product_code"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("instance_id"), parts[1])...)
+	if len(parts) == 3 && parts[2] != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("product_type"), parts[2])...)
+	}
+}
+
+func (r *bssInstanceRenewalResource) setRenewal(plan *bssInstanceRenewalResourceModel) error {
+	setRenewalRequest := &alicloudBaseClient.SetRenewalRequest{
+		InstanceIDs:   tea.String(plan.InstanceId.ValueString()),
+		RenewalStatus: tea.String(plan.RenewalStatus.ValueString()),
+		ProductCode:   tea.String(plan.ProductCode.ValueString()),
+	}
+	if !plan.ProductType.IsNull() {
+		setRenewalRequest.ProductType = tea.String(plan.ProductType.ValueString())
+	}
+	if plan.RenewalStatus.ValueString() == "AutoRenewal" {
+		setRenewalRequest.RenewalPeriod = tea.Int32(int32(plan.RenewPeriod.ValueInt64()))
+		setRenewalRequest.RenewalPeriodUnit = tea.String("M")
+	}
+
+	return onBssEndpoints(func(endpoint string) error {
+		return setBssInstanceRenewal(r.client, endpoint, setRenewalRequest)
+	})
+}
+
+// readInstance sets the computed attributes of the model from the instance.
+func (r *bssInstanceRenewalResource) readInstance(model *bssInstanceRenewalResourceModel) error {
+	var availableInstance *alicloudBaseClient.QueryAvailableInstancesResponseBodyDataInstanceList
+	err := onBssEndpoints(func(endpoint string) (err error) {
+		availableInstance, err = queryBssAvailableInstance(r.client, endpoint, model.ProductCode.ValueString(), model.ProductType.ValueString(), model.InstanceId.ValueString())
+		return
+	})
+	if err != nil {
+		return err
+	}
+	if availableInstance == nil {
+		return fmt.Errorf("instance %s of product %s is not found", model.InstanceId.ValueString(), model.ProductCode.ValueString())
+	}
+
+	model.Status = types.StringValue(tea.StringValue(availableInstance.Status))
+	model.EndTime = types.StringValue(tea.StringValue(availableInstance.EndTime))
+	return nil
+}
+
+// setBssInstanceRenewal sets the renewal of the subscription instances on
+// the BSS endpoint, with a client of its own so that the endpoint of the
+// provider client is never changed.
+func setBssInstanceRenewal(providerClient *alicloudBaseClient.Client, endpoint string, req *alicloudBaseClient.SetRenewalRequest) error {
+	client, err := newBssClient(providerClient, endpoint)
+	if err != nil {
+		return err
+	}
+
+	setRenewal := func() error {
+		runtime := &util.RuntimeOptions{}
+		_, err := client.SetRenewalWithOptions(req, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(setRenewal, reconnectBackoff)
+}
+
+// queryBssAvailableInstance returns the subscription instance from the BSS
+// endpoint, or nil if the instance is not found.
+func queryBssAvailableInstance(providerClient *alicloudBaseClient.Client, endpoint, productCode, productType, instanceId string) (*alicloudBaseClient.QueryAvailableInstancesResponseBodyDataInstanceList, error) {
+	client, err := newBssClient(providerClient, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var availableInstance *alicloudBaseClient.QueryAvailableInstancesResponseBodyDataInstanceList
+	queryAvailableInstances := func() error {
+		runtime := &util.RuntimeOptions{}
+		availableInstance = nil
+
+		queryAvailableInstancesRequest := &alicloudBaseClient.QueryAvailableInstancesRequest{
+			InstanceIDs: tea.String(instanceId),
+			ProductCode: tea.String(productCode),
+		}
+		if productType != "" {
+			queryAvailableInstancesRequest.ProductType = tea.String(productType)
+		}
+
+		queryAvailableInstancesResponse, err := client.QueryAvailableInstancesWithOptions(queryAvailableInstancesRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		if data := queryAvailableInstancesResponse.Body.Data; data != nil {
+			for _, instance := range data.InstanceList {
+				if tea.StringValue(instance.InstanceID) == instanceId {
+					availableInstance = instance
+				}
+			}
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(queryAvailableInstances, reconnectBackoff); err != nil {
+		return nil, err
+	}
+	return availableInstance, nil
+}
+
+// onBssEndpoints calls the function on the China endpoint of BSS, then on the
+// international endpoint if the instances are not applicable to the China
+// one, for the instances whose site is unknown. The instances are only
+// applicable to the endpoint of the site where they are purchased.
+func onBssEndpoints(call func(endpoint string) error) error {
+	err := call(bssChinaEndpoint)
+	if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == ERR_NOT_APPLICABLE {
+		return call(bssInternationalEndpoint)
+	}
+	return err
+}
@@ -0,0 +1,839 @@
+package alicloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudRamClient "github.com/alibabacloud-go/ram-20150501/v2/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                   = &ramAccessKeyResource{}
+	_ resource.ResourceWithConfigure      = &ramAccessKeyResource{}
+	_ resource.ResourceWithValidateConfig = &ramAccessKeyResource{}
+	_ resource.ResourceWithModifyPlan     = &ramAccessKeyResource{}
+)
+
+// ramAccessKeyReplacedPrivateKey is the private state key of the access key
+// replaced for rotation, which is passed from the plan of the replaced
+// resource to the plan of its replacement.
+const ramAccessKeyReplacedPrivateKey = "replaced_access_key_id"
+
+// ramAccessKeysTakenOver records the access keys taken over as the previous
+// access keys by their replacements in this run. The replaced resources are
+// destroyed after their replacements are created with create_before_destroy,
+// and they keep the access keys recorded here.
+var ramAccessKeysTakenOver sync.Map
+
+func NewRamAccessKeyResource() resource.Resource {
+	return &ramAccessKeyResource{}
+}
+
+type ramAccessKeyResource struct {
+	client *alicloudRamClient.Client
+}
+
+type ramAccessKeyResourceModel struct {
+	UserName              types.String `tfsdk:"user_name"`
+	Status                types.String `tfsdk:"status"`
+	RotationDays          types.Int64  `tfsdk:"rotation_days"`
+	GracePeriodDays       types.Int64  `tfsdk:"grace_period_days"`
+	PgpKey                types.String `tfsdk:"pgp_key"`
+	AgePublicKey          types.String `tfsdk:"age_public_key"`
+	AccessKeyId           types.String `tfsdk:"access_key_id"`
+	Secret                types.String `tfsdk:"secret"`
+	EncryptedSecret       types.String `tfsdk:"encrypted_secret"`
+	KeyFingerprint        types.String `tfsdk:"key_fingerprint"`
+	CreateDate            types.String `tfsdk:"create_date"`
+	RotationDate          types.String `tfsdk:"rotation_date"`
+	PreviousAccessKeyId   types.String `tfsdk:"previous_access_key_id"`
+	PreviousKeyExpireDate types.String `tfsdk:"previous_key_expire_date"`
+}
+
+func (r *ramAccessKeyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ram_access_key"
+}
+
+func (r *ramAccessKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a RAM Access Key resource with rotation. When the access key is older than " +
+			"the rotation days, the resource is planned to be replaced by a new access key. The old access key " +
+			"is kept during the grace period before it is deleted, only if the lifecycle of the resource creates " +
+			"the new access key before destroying the old one.",
+		Attributes: map[string]schema.Attribute{
+			"user_name": schema.StringAttribute{
+				Description: "The name of the RAM user.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "The status of the access key. Valid values: Active, Inactive, default to Active.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Active", "Inactive"),
+				},
+				Default: stringdefault.StaticString("Active"),
+			},
+			"rotation_days": schema.Int64Attribute{
+				Description: "The number of days after which the access key is rotated. The access key is not rotated if it is not set.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"grace_period_days": schema.Int64Attribute{
+				Description: "The number of days to keep the old access key after rotation, it must be less than " +
+					"rotation_days. Default to 0, which deletes the old access key immediately.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				Default: int64default.StaticInt64(0),
+			},
+			"pgp_key": schema.StringAttribute{
+				Description: "A base64 encoded or ASCII armored PGP public key to encrypt the secret.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("age_public_key")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"age_public_key": schema.StringAttribute{
+				Description: "An age public key to encrypt the secret, e.g. age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"access_key_id": schema.StringAttribute{
+				Description: "The ID of the access key.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"secret": schema.StringAttribute{
+				Description: "The secret of the access key. It is only set when neither pgp_key nor age_public_key is configured.",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"encrypted_secret": schema.StringAttribute{
+				Description: "The encrypted secret of the access key. It is a base64 encoded PGP message when pgp_key " +
+					"is configured, or an ASCII armored age file when age_public_key is configured.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key_fingerprint": schema.StringAttribute{
+				Description: "The fingerprint of the PGP key used to encrypt the secret.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"create_date": schema.StringAttribute{
+				Description: "The create date of the access key.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rotation_date": schema.StringAttribute{
+				Description: "The date when the access key is due for rotation.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					ramAccessKeyRotationDateModifier{},
+					stringplanmodifier.RequiresReplaceIf(
+						func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = req.PlanValue.IsUnknown()
+						},
+						"The access key is replaced when it is due for rotation.",
+						"The access key is replaced when it is due for rotation.",
+					),
+				},
+			},
+			"previous_access_key_id": schema.StringAttribute{
+				Description: "The ID of the old access key that is kept during the grace period.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"previous_key_expire_date": schema.StringAttribute{
+				Description: "The date when the old access key will be deleted.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ramAccessKeyResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).ramClient
+}
+
+func (r *ramAccessKeyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *ramAccessKeyResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !(config.PgpKey.IsNull() || config.PgpKey.IsUnknown()) {
+		if _, err := parsePgpPublicKey(config.PgpKey.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("pgp_key"),
+				"[Input Error] Invalid PGP Key",
+				err.Error(),
+			)
+		}
+	}
+
+	if !(config.AgePublicKey.IsNull() || config.AgePublicKey.IsUnknown()) {
+		if _, err := age.ParseX25519Recipient(config.AgePublicKey.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("age_public_key"),
+				"[Input Error] Invalid Age Public Key",
+				err.Error(),
+			)
+		}
+	}
+
+	// The old access key must be deleted before the next rotation, as a RAM
+	// user can only have two access keys.
+	if !(config.RotationDays.IsNull() || config.RotationDays.IsUnknown() ||
+		config.GracePeriodDays.IsNull() || config.GracePeriodDays.IsUnknown()) &&
+		config.GracePeriodDays.ValueInt64() >= config.RotationDays.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("grace_period_days"),
+			"[Input Error] Invalid Grace Period",
+			"grace_period_days must be less than rotation_days.",
+		)
+	}
+}
+
+// ModifyPlan plans the computed attributes of the new access key when it is
+// replaced for rotation, and the deletion of the old access key when the grace
+// period is over. The replaced access key is planned as the previous access
+// key of its replacement.
+func (r *ramAccessKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Skip on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state *ramAccessKeyResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		replacedAccessKeyId, diags := req.Private.GetKey(ctx, ramAccessKeyReplacedPrivateKey)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() || replacedAccessKeyId == nil {
+			return
+		}
+
+		var accessKeyId string
+		if err := json.Unmarshal(replacedAccessKeyId, &accessKeyId); err != nil {
+			resp.Diagnostics.AddError(
+				"[ERROR] Failed to Read Replaced Access Key",
+				err.Error(),
+			)
+			return
+		}
+		plan.PreviousAccessKeyId = types.StringValue(accessKeyId)
+
+		setPlanDiags := resp.Plan.Set(ctx, &plan)
+		resp.Diagnostics.Append(setPlanDiags...)
+		return
+	}
+
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if isRamAccessKeyDatePassed(state.PreviousKeyExpireDate) {
+		plan.PreviousAccessKeyId = types.StringNull()
+		plan.PreviousKeyExpireDate = types.StringNull()
+	}
+
+	if plan.RotationDate.IsUnknown() {
+		plan.AccessKeyId = types.StringUnknown()
+		plan.Secret = types.StringUnknown()
+		plan.EncryptedSecret = types.StringUnknown()
+		plan.KeyFingerprint = types.StringUnknown()
+		plan.CreateDate = types.StringUnknown()
+		plan.PreviousAccessKeyId = types.StringUnknown()
+		plan.PreviousKeyExpireDate = types.StringUnknown()
+
+		// Pass the access key to the plan of the replacement, which keeps it
+		// during the grace period.
+		if plan.GracePeriodDays.ValueInt64() > 0 && plan.UserName.Equal(state.UserName) {
+			replacedAccessKeyId, err := json.Marshal(state.AccessKeyId.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"[ERROR] Failed to Plan Replaced Access Key",
+					err.Error(),
+				)
+				return
+			}
+			resp.Diagnostics.Append(resp.Private.SetKey(ctx, ramAccessKeyReplacedPrivateKey, replacedAccessKeyId)...)
+		}
+	} else if isRamAccessKeyDatePassed(plan.RotationDate) {
+		resp.Diagnostics.AddWarning(
+			"[Input Warning] Access Key Rotation Postponed",
+			fmt.Sprintf("The access key %s is due for rotation, but the old access key %s is not deleted yet. "+
+				"The rotation will be planned after the old access key is deleted on %s.",
+				state.AccessKeyId.ValueString(), state.PreviousAccessKeyId.ValueString(),
+				state.PreviousKeyExpireDate.ValueString()),
+		)
+	}
+
+	setPlanDiags := resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(setPlanDiags...)
+}
+
+func (r *ramAccessKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *ramAccessKeyResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := &ramAccessKeyResourceModel{
+		UserName:              plan.UserName,
+		Status:                plan.Status,
+		RotationDays:          plan.RotationDays,
+		GracePeriodDays:       plan.GracePeriodDays,
+		PgpKey:                plan.PgpKey,
+		AgePublicKey:          plan.AgePublicKey,
+		PreviousAccessKeyId:   types.StringNull(),
+		PreviousKeyExpireDate: types.StringNull(),
+	}
+
+	if err := r.createAccessKey(state); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Create Access Key",
+			err.Error(),
+		)
+		return
+	}
+
+	// Keep the access key replaced for rotation during the grace period. The
+	// error is only a warning, as the new access key must be saved to the
+	// state.
+	if !plan.PreviousAccessKeyId.IsNull() && !plan.PreviousAccessKeyId.IsUnknown() {
+		state.PreviousAccessKeyId = plan.PreviousAccessKeyId
+		if err := r.keepPreviousAccessKey(state); err != nil {
+			resp.Diagnostics.AddWarning(
+				"[API WARNING] Failed to Keep Old Access Key",
+				err.Error(),
+			)
+		} else {
+			resp.Diagnostics.AddWarning(
+				"[API WARNING] Old Access Key Kept",
+				fmt.Sprintf("The access key %s of user %s is due for rotation, it is kept until %s and will be deleted after that.",
+					state.PreviousAccessKeyId.ValueString(), state.UserName.ValueString(), state.PreviousKeyExpireDate.ValueString()),
+			)
+		}
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramAccessKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *ramAccessKeyResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accessKeys, err := r.listAccessKeys(state.UserName.ValueString())
+	if err != nil {
+		if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == ERR_ENTITY_NOT_EXIST_USER {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read Access Keys",
+			err.Error(),
+		)
+		return
+	}
+
+	accessKey, ok := accessKeys[state.AccessKeyId.ValueString()]
+	if !ok {
+		resp.Diagnostics.AddWarning(
+			"[API WARNING] Access Key Deleted Outside of Terraform",
+			fmt.Sprintf("The access key %s of user %s is not found, a new access key will be created.",
+				state.AccessKeyId.ValueString(), state.UserName.ValueString()),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.Status = types.StringValue(tea.StringValue(accessKey.Status))
+	state.CreateDate = types.StringValue(tea.StringValue(accessKey.CreateDate))
+	rotationDate, err := getRamAccessKeyRotationDate(state.CreateDate, state.RotationDays)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[ERROR] Failed to Parse Access Key Create Date",
+			err.Error(),
+		)
+		return
+	}
+	state.RotationDate = rotationDate
+
+	if !state.PreviousAccessKeyId.IsNull() {
+		if _, ok := accessKeys[state.PreviousAccessKeyId.ValueString()]; !ok {
+			state.PreviousAccessKeyId = types.StringNull()
+			state.PreviousKeyExpireDate = types.StringNull()
+		}
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramAccessKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *ramAccessKeyResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete the old access key when the grace period is over.
+	if !state.PreviousAccessKeyId.IsNull() && plan.PreviousAccessKeyId.IsNull() {
+		if err := r.deleteAccessKey(state.UserName.ValueString(), state.PreviousAccessKeyId.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Delete Old Access Key",
+				err.Error(),
+			)
+			return
+		}
+		state.PreviousAccessKeyId = types.StringNull()
+		state.PreviousKeyExpireDate = types.StringNull()
+	}
+
+	state.Status = plan.Status
+	state.RotationDays = plan.RotationDays
+	state.GracePeriodDays = plan.GracePeriodDays
+	state.RotationDate = plan.RotationDate
+
+	if err := r.updateAccessKeyStatus(state); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update Access Key",
+			err.Error(),
+		)
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramAccessKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *ramAccessKeyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The access key is only kept when it has been taken over by its
+	// replacement for rotation in this run.
+	accessKeyIds := []string{}
+	if _, ok := ramAccessKeysTakenOver.Load(state.AccessKeyId.ValueString()); !ok {
+		accessKeyIds = append(accessKeyIds, state.AccessKeyId.ValueString())
+	}
+	if !state.PreviousAccessKeyId.IsNull() {
+		accessKeyIds = append(accessKeyIds, state.PreviousAccessKeyId.ValueString())
+	}
+
+	for _, accessKeyId := range accessKeyIds {
+		if err := r.deleteAccessKey(state.UserName.ValueString(), accessKeyId); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Delete Access Key",
+				err.Error(),
+			)
+			return
+		}
+	}
+}
+
+// createAccessKey creates a new access key for the user and sets it to the
+// state, together with the secret or the encrypted secret.
+func (r *ramAccessKeyResource) createAccessKey(state *ramAccessKeyResourceModel) error {
+	var accessKey *alicloudRamClient.CreateAccessKeyResponseBodyAccessKey
+
+	createAccessKey := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		createAccessKeyRequest := &alicloudRamClient.CreateAccessKeyRequest{
+			UserName: tea.String(state.UserName.ValueString()),
+		}
+
+		createAccessKeyResponse, err := r.client.CreateAccessKeyWithOptions(createAccessKeyRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		accessKey = createAccessKeyResponse.Body.AccessKey
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(createAccessKey, reconnectBackoff); err != nil {
+		return err
+	}
+
+	state.AccessKeyId = types.StringValue(tea.StringValue(accessKey.AccessKeyId))
+	state.CreateDate = types.StringValue(tea.StringValue(accessKey.CreateDate))
+
+	// The access key is deleted if it fails to be set up, otherwise it is left
+	// without being saved to the state, and its secret can not be read again.
+	if err := r.setUpAccessKey(state, accessKey); err != nil {
+		if deleteErr := r.deleteAccessKey(state.UserName.ValueString(), state.AccessKeyId.ValueString()); deleteErr != nil {
+			return fmt.Errorf("%v, and the access key %s failed to be deleted: %v", err, state.AccessKeyId.ValueString(), deleteErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// setUpAccessKey sets the secret or the encrypted secret of the new access key
+// to the state, and updates its status as configured.
+func (r *ramAccessKeyResource) setUpAccessKey(state *ramAccessKeyResourceModel, accessKey *alicloudRamClient.CreateAccessKeyResponseBodyAccessKey) error {
+	rotationDate, err := getRamAccessKeyRotationDate(state.CreateDate, state.RotationDays)
+	if err != nil {
+		return err
+	}
+	state.RotationDate = rotationDate
+	state.Secret = types.StringNull()
+	state.EncryptedSecret = types.StringNull()
+	state.KeyFingerprint = types.StringNull()
+
+	secret := tea.StringValue(accessKey.AccessKeySecret)
+	switch {
+	case !state.PgpKey.IsNull():
+		encryptedSecret, fingerprint, err := encryptWithPgpPublicKey(secret, state.PgpKey.ValueString())
+		if err != nil {
+			return err
+		}
+		state.EncryptedSecret = types.StringValue(encryptedSecret)
+		state.KeyFingerprint = types.StringValue(fingerprint)
+	case !state.AgePublicKey.IsNull():
+		encryptedSecret, err := encryptWithAgePublicKey(secret, state.AgePublicKey.ValueString())
+		if err != nil {
+			return err
+		}
+		state.EncryptedSecret = types.StringValue(encryptedSecret)
+	default:
+		state.Secret = types.StringValue(secret)
+	}
+
+	if tea.StringValue(accessKey.Status) != state.Status.ValueString() {
+		return r.updateAccessKeyStatus(state)
+	}
+	return nil
+}
+
+// keepPreviousAccessKey keeps the previous access key replaced for rotation
+// until the end of the grace period. The previous access key has already
+// been deleted if the replaced resource was destroyed before the replacement
+// was created, then it expires immediately.
+func (r *ramAccessKeyResource) keepPreviousAccessKey(state *ramAccessKeyResourceModel) error {
+	state.PreviousKeyExpireDate = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	accessKeys, err := r.listAccessKeys(state.UserName.ValueString())
+	if err != nil {
+		return err
+	}
+	if _, ok := accessKeys[state.PreviousAccessKeyId.ValueString()]; !ok {
+		return fmt.Errorf("the access key %s of user %s is already deleted, the resource must be replaced with "+
+			"create_before_destroy to keep it during the grace period", state.PreviousAccessKeyId.ValueString(), state.UserName.ValueString())
+	}
+
+	state.PreviousKeyExpireDate = types.StringValue(time.Now().UTC().
+		AddDate(0, 0, int(state.GracePeriodDays.ValueInt64())).Format(time.RFC3339))
+	ramAccessKeysTakenOver.Store(state.PreviousAccessKeyId.ValueString(), true)
+	return nil
+}
+
+func (r *ramAccessKeyResource) updateAccessKeyStatus(state *ramAccessKeyResourceModel) error {
+	updateAccessKey := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		updateAccessKeyRequest := &alicloudRamClient.UpdateAccessKeyRequest{
+			UserName:        tea.String(state.UserName.ValueString()),
+			UserAccessKeyId: tea.String(state.AccessKeyId.ValueString()),
+			Status:          tea.String(state.Status.ValueString()),
+		}
+
+		if _, err := r.client.UpdateAccessKeyWithOptions(updateAccessKeyRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(updateAccessKey, reconnectBackoff)
+}
+
+// deleteAccessKey deletes the access key of the user, the access key that is
+// already deleted is ignored.
+func (r *ramAccessKeyResource) deleteAccessKey(userName, accessKeyId string) error {
+	deleteAccessKey := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		deleteAccessKeyRequest := &alicloudRamClient.DeleteAccessKeyRequest{
+			UserName:        tea.String(userName),
+			UserAccessKeyId: tea.String(accessKeyId),
+		}
+
+		if _, err := r.client.DeleteAccessKeyWithOptions(deleteAccessKeyRequest, runtime); err != nil {
+			if _t, ok := err.(*tea.SDKError); ok {
+				switch tea.StringValue(_t.Code) {
+				case ERR_ENTITY_NOT_EXIST_USER, ERR_ENTITY_NOT_EXIST_ACCESS_KEY:
+					return nil
+				}
+			}
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(deleteAccessKey, reconnectBackoff)
+}
+
+// listAccessKeys returns the access keys of the user, keyed by the access key ID.
+func (r *ramAccessKeyResource) listAccessKeys(userName string) (accessKeys map[string]*alicloudRamClient.ListAccessKeysResponseBodyAccessKeysAccessKey, err error) {
+	listAccessKeys := func() error {
+		runtime := &util.RuntimeOptions{}
+		accessKeys = map[string]*alicloudRamClient.ListAccessKeysResponseBodyAccessKeysAccessKey{}
+
+		listAccessKeysRequest := &alicloudRamClient.ListAccessKeysRequest{
+			UserName: tea.String(userName),
+		}
+
+		listAccessKeysResponse, err := r.client.ListAccessKeysWithOptions(listAccessKeysRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+
+		if listAccessKeysResponse.Body.AccessKeys != nil {
+			for _, accessKey := range listAccessKeysResponse.Body.AccessKeys.AccessKey {
+				accessKeys[tea.StringValue(accessKey.AccessKeyId)] = accessKey
+			}
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(listAccessKeys, reconnectBackoff)
+	return
+}
+
+// getRamAccessKeyRotationDate returns the date when the access key is due for
+// rotation, or null if the access key is not rotated.
+func getRamAccessKeyRotationDate(createDate types.String, rotationDays types.Int64) (types.String, error) {
+	if createDate.IsNull() || createDate.IsUnknown() || rotationDays.IsNull() || rotationDays.IsUnknown() {
+		return types.StringNull(), nil
+	}
+
+	date, err := time.Parse(time.RFC3339, createDate.ValueString())
+	if err != nil {
+		return types.StringNull(), err
+	}
+	return types.StringValue(date.UTC().AddDate(0, 0, int(rotationDays.ValueInt64())).Format(time.RFC3339)), nil
+}
+
+// isRamAccessKeyDatePassed returns whether the date in RFC3339 is passed.
+func isRamAccessKeyDatePassed(date types.String) bool {
+	if date.IsNull() || date.IsUnknown() {
+		return false
+	}
+
+	parsed, err := time.Parse(time.RFC3339, date.ValueString())
+	return err == nil && !time.Now().Before(parsed)
+}
+
+// ramAccessKeyRotationDateModifier plans the rotation date from the create
+// date of the access key, and plans it as unknown when the access key is due
+// for rotation, so that the access key is replaced. The rotation is postponed
+// while the old access key is kept, as a RAM user can only have two access
+// keys.
+type ramAccessKeyRotationDateModifier struct{}
+
+func (m ramAccessKeyRotationDateModifier) Description(_ context.Context) string {
+	return "Plan the rotation date as unknown when the access key is due for rotation."
+}
+
+func (m ramAccessKeyRotationDateModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m ramAccessKeyRotationDateModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Skip on create and destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var rotationDays types.Int64
+	var createDate, previousAccessKeyId types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rotation_days"), &rotationDays)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("create_date"), &createDate)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("previous_access_key_id"), &previousAccessKeyId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if rotationDays.IsUnknown() {
+		resp.PlanValue = req.StateValue
+		return
+	}
+
+	rotationDate, err := getRamAccessKeyRotationDate(createDate, rotationDays)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[ERROR] Failed to Parse Access Key Create Date",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.PlanValue = rotationDate
+	if isRamAccessKeyDatePassed(rotationDate) && previousAccessKeyId.IsNull() {
+		resp.PlanValue = types.StringUnknown()
+	}
+}
+
+// parsePgpPublicKey parses a base64 encoded or ASCII armored PGP public key.
+func parsePgpPublicKey(pgpKey string) (openpgp.EntityList, error) {
+	if strings.Contains(pgpKey, "-----BEGIN PGP PUBLIC KEY BLOCK-----") {
+		return openpgp.ReadArmoredKeyRing(strings.NewReader(pgpKey))
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(pgpKey))
+	if err != nil {
+		return nil, fmt.Errorf("the PGP key is neither base64 encoded nor ASCII armored: %w", err)
+	}
+	return openpgp.ReadKeyRing(bytes.NewReader(decoded))
+}
+
+// encryptWithPgpPublicKey encrypts the plaintext with the PGP public key and
+// returns the base64 encoded message and the fingerprint of the key.
+func encryptWithPgpPublicKey(plaintext, pgpKey string) (string, string, error) {
+	entities, err := parsePgpPublicKey(pgpKey)
+	if err != nil {
+		return "", "", err
+	}
+	if len(entities) == 0 {
+		return "", "", fmt.Errorf("no PGP key is found")
+	}
+
+	buffer := &bytes.Buffer{}
+	writer, err := openpgp.Encrypt(buffer, entities[:1], nil, nil, nil)
+	if err != nil {
+		return "", "", err
+	}
+	if _, err := writer.Write([]byte(plaintext)); err != nil {
+		return "", "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(buffer.Bytes()), hex.EncodeToString(entities[0].PrimaryKey.Fingerprint), nil
+}
+
+// encryptWithAgePublicKey encrypts the plaintext with the age public key and
+// returns the ASCII armored age file.
+func encryptWithAgePublicKey(plaintext, agePublicKey string) (string, error) {
+	recipient, err := age.ParseX25519Recipient(agePublicKey)
+	if err != nil {
+		return "", err
+	}
+
+	buffer := &bytes.Buffer{}
+	armorWriter := armor.NewWriter(buffer)
+	writer, err := age.Encrypt(armorWriter, recipient)
+	if err != nil {
+		return "", err
+	}
+	if _, err := writer.Write([]byte(plaintext)); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	if err := armorWriter.Close(); err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
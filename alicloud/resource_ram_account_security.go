@@ -0,0 +1,454 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	openapi "github.com/alibabacloud-go/darabonba-openapi/v2/client"
+	alicloudRamClient "github.com/alibabacloud-go/ram-20150501/v2/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                = &ramAccountSecurityResource{}
+	_ resource.ResourceWithConfigure   = &ramAccountSecurityResource{}
+	_ resource.ResourceWithImportState = &ramAccountSecurityResource{}
+)
+
+func NewRamAccountSecurityResource() resource.Resource {
+	return &ramAccountSecurityResource{}
+}
+
+type ramAccountSecurityResource struct {
+	client *alicloudRamClient.Client
+}
+
+type ramAccountSecurityResourceModel struct {
+	MinimumPasswordLength       types.Int64 `tfsdk:"minimum_password_length"`
+	RequireLowercaseCharacters  types.Bool  `tfsdk:"require_lowercase_characters"`
+	RequireUppercaseCharacters  types.Bool  `tfsdk:"require_uppercase_characters"`
+	RequireNumbers              types.Bool  `tfsdk:"require_numbers"`
+	RequireSymbols              types.Bool  `tfsdk:"require_symbols"`
+	MaxPasswordAge              types.Int64 `tfsdk:"max_password_age"`
+	PasswordReusePrevention     types.Int64 `tfsdk:"password_reuse_prevention"`
+	MaxLoginAttempts            types.Int64 `tfsdk:"max_login_attempts"`
+	HardExpiry                  types.Bool  `tfsdk:"hard_expiry"`
+	EnforceMfaForLogin          types.Bool  `tfsdk:"enforce_mfa_for_login"`
+	EnableSaveMfaTicket         types.Bool  `tfsdk:"enable_save_mfa_ticket"`
+	LoginNetworkMasks           types.Set   `tfsdk:"login_network_masks"`
+	LoginSessionDuration        types.Int64 `tfsdk:"login_session_duration"`
+	AllowUserToChangePassword   types.Bool  `tfsdk:"allow_user_to_change_password"`
+	AllowUserToManageAccessKeys types.Bool  `tfsdk:"allow_user_to_manage_access_keys"`
+	AllowUserToManageMfaDevices types.Bool  `tfsdk:"allow_user_to_manage_mfa_devices"`
+}
+
+// The SDK does not support EnforceMFAForLogin yet, so the security preference
+// is set and read through CallApi with these types.
+type ramSecurityPreferenceResponse struct {
+	Body struct {
+		SecurityPreference *ramSecurityPreference `json:"SecurityPreference"`
+	} `json:"body"`
+}
+
+type ramSecurityPreference struct {
+	LoginProfilePreference *struct {
+		EnforceMFAForLogin        *bool   `json:"EnforceMFAForLogin"`
+		EnableSaveMFATicket       *bool   `json:"EnableSaveMFATicket"`
+		LoginNetworkMasks         *string `json:"LoginNetworkMasks"`
+		LoginSessionDuration      *int32  `json:"LoginSessionDuration"`
+		AllowUserToChangePassword *bool   `json:"AllowUserToChangePassword"`
+	} `json:"LoginProfilePreference"`
+	AccessKeyPreference *struct {
+		AllowUserToManageAccessKeys *bool `json:"AllowUserToManageAccessKeys"`
+	} `json:"AccessKeyPreference"`
+	MFAPreference *struct {
+		AllowUserToManageMFADevices *bool `json:"AllowUserToManageMFADevices"`
+	} `json:"MFAPreference"`
+}
+
+func (r *ramAccountSecurityResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ram_account_security"
+}
+
+func (r *ramAccountSecurityResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	optionalBool := func(description string) schema.BoolAttribute {
+		return schema.BoolAttribute{
+			Description: description,
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+	optionalInt64 := func(description string, min, max int64) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Description: description,
+			Optional:    true,
+			Computed:    true,
+			Validators: []validator.Int64{
+				int64validator.Between(min, max),
+			},
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Provides a RAM Account Security resource that manages the password policy and the security " +
+			"preference of the account. There is only one such resource per account. The settings that are not " +
+			"configured are left unchanged, and destroying the resource only removes it from the state.",
+		Attributes: map[string]schema.Attribute{
+			"minimum_password_length": optionalInt64(
+				"The minimum number of characters of the passwords. Valid values: 8 to 32.", 8, 32),
+			"require_lowercase_characters": optionalBool(
+				"Whether the passwords must contain lowercase letters."),
+			"require_uppercase_characters": optionalBool(
+				"Whether the passwords must contain uppercase letters."),
+			"require_numbers": optionalBool(
+				"Whether the passwords must contain digits."),
+			"require_symbols": optionalBool(
+				"Whether the passwords must contain special characters."),
+			"max_password_age": optionalInt64(
+				"The number of days for which the passwords are valid. Valid values: 0 to 1095, 0 means the passwords never expire.", 0, 1095),
+			"password_reuse_prevention": optionalInt64(
+				"The number of previous passwords that can not be reused. Valid values: 0 to 24.", 0, 24),
+			"max_login_attempts": optionalInt64(
+				"The maximum number of failed login attempts within an hour before the login is locked. "+
+					"Valid values: 0 to 32, 0 means the login is never locked.", 0, 32),
+			"hard_expiry": optionalBool(
+				"Whether the users are prevented from logging on after their passwords expire."),
+			"enforce_mfa_for_login": optionalBool(
+				"Whether MFA is required for all the users to log on to the console."),
+			"enable_save_mfa_ticket": optionalBool(
+				"Whether the users can save the MFA security code for seven days during the console login."),
+			"login_network_masks": schema.SetAttribute{
+				Description: "The subnet masks that the users can log on to the console from, e.g. 10.0.0.0/8. " +
+					"An empty set allows the console login from any network.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"login_session_duration": optionalInt64(
+				"The validity period of the console login sessions in hours. Valid values: 1 to 24.", 1, 24),
+			"allow_user_to_change_password": optionalBool(
+				"Whether the users can change their passwords."),
+			"allow_user_to_manage_access_keys": optionalBool(
+				"Whether the users can manage their access keys."),
+			"allow_user_to_manage_mfa_devices": optionalBool(
+				"Whether the users can bind and unbind their MFA devices."),
+		},
+	}
+}
+
+func (r *ramAccountSecurityResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).ramClient
+}
+
+func (r *ramAccountSecurityResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *ramAccountSecurityResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setAccountSecurity(ctx, plan); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Set Account Security",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &ramAccountSecurityResourceModel{}
+	if err := r.readAccountSecurity(ctx, state); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read Account Security",
+			err.Error(),
+		)
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramAccountSecurityResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *ramAccountSecurityResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readAccountSecurity(ctx, state); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read Account Security",
+			err.Error(),
+		)
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramAccountSecurityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *ramAccountSecurityResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setAccountSecurity(ctx, plan); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Set Account Security",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &ramAccountSecurityResourceModel{}
+	if err := r.readAccountSecurity(ctx, state); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read Account Security",
+			err.Error(),
+		)
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// The account security settings can not be deleted, they are only removed
+// from the state.
+func (r *ramAccountSecurityResource) Delete(_ context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.AddWarning(
+		"[API WARNING] Account Security Settings Unchanged",
+		"The account security settings can not be deleted, they are only removed from the state.",
+	)
+}
+
+// ImportState ignores the ID, as there is only one account security setting per account.
+func (r *ramAccountSecurityResource) ImportState(ctx context.Context, _ resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := &ramAccountSecurityResourceModel{
+		LoginNetworkMasks: types.SetNull(types.StringType),
+	}
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+}
+
+func (r *ramAccountSecurityResource) setAccountSecurity(ctx context.Context, plan *ramAccountSecurityResourceModel) error {
+	isSet := func(value interface {
+		IsNull() bool
+		IsUnknown() bool
+	}) bool {
+		return !(value.IsNull() || value.IsUnknown())
+	}
+
+	// SetPasswordPolicy and SetSecurityPreference reset the settings that are
+	// not sent to their defaults, so the configured settings are merged into
+	// the current settings, and all of them are sent.
+	current := &ramAccountSecurityResourceModel{}
+	if err := r.readAccountSecurity(ctx, current); err != nil {
+		return err
+	}
+	int64Value := func(planValue, currentValue types.Int64) types.Int64 {
+		if isSet(planValue) {
+			return planValue
+		}
+		return currentValue
+	}
+	boolValue := func(planValue, currentValue types.Bool) types.Bool {
+		if isSet(planValue) {
+			return planValue
+		}
+		return currentValue
+	}
+
+	setPasswordPolicyRequest := &alicloudRamClient.SetPasswordPolicyRequest{
+		MinimumPasswordLength:      tea.Int32(int32(int64Value(plan.MinimumPasswordLength, current.MinimumPasswordLength).ValueInt64())),
+		RequireLowercaseCharacters: tea.Bool(boolValue(plan.RequireLowercaseCharacters, current.RequireLowercaseCharacters).ValueBool()),
+		RequireUppercaseCharacters: tea.Bool(boolValue(plan.RequireUppercaseCharacters, current.RequireUppercaseCharacters).ValueBool()),
+		RequireNumbers:             tea.Bool(boolValue(plan.RequireNumbers, current.RequireNumbers).ValueBool()),
+		RequireSymbols:             tea.Bool(boolValue(plan.RequireSymbols, current.RequireSymbols).ValueBool()),
+		MaxPasswordAge:             tea.Int32(int32(int64Value(plan.MaxPasswordAge, current.MaxPasswordAge).ValueInt64())),
+		PasswordReusePrevention:    tea.Int32(int32(int64Value(plan.PasswordReusePrevention, current.PasswordReusePrevention).ValueInt64())),
+		MaxLoginAttemps:            tea.Int32(int32(int64Value(plan.MaxLoginAttempts, current.MaxLoginAttempts).ValueInt64())),
+		HardExpiry:                 tea.Bool(boolValue(plan.HardExpiry, current.HardExpiry).ValueBool()),
+	}
+
+	securityPreferenceQuery := map[string]*string{}
+	for key, value := range map[string]types.Bool{
+		"EnforceMFAForLogin":          boolValue(plan.EnforceMfaForLogin, current.EnforceMfaForLogin),
+		"EnableSaveMFATicket":         boolValue(plan.EnableSaveMfaTicket, current.EnableSaveMfaTicket),
+		"AllowUserToChangePassword":   boolValue(plan.AllowUserToChangePassword, current.AllowUserToChangePassword),
+		"AllowUserToManageAccessKeys": boolValue(plan.AllowUserToManageAccessKeys, current.AllowUserToManageAccessKeys),
+		"AllowUserToManageMFADevices": boolValue(plan.AllowUserToManageMfaDevices, current.AllowUserToManageMfaDevices),
+	} {
+		if isSet(value) {
+			securityPreferenceQuery[key] = tea.String(strconv.FormatBool(value.ValueBool()))
+		}
+	}
+	if loginSessionDuration := int64Value(plan.LoginSessionDuration, current.LoginSessionDuration); isSet(loginSessionDuration) {
+		securityPreferenceQuery["LoginSessionDuration"] = tea.String(strconv.FormatInt(loginSessionDuration.ValueInt64(), 10))
+	}
+	loginNetworkMasksValue := current.LoginNetworkMasks
+	if isSet(plan.LoginNetworkMasks) {
+		loginNetworkMasksValue = plan.LoginNetworkMasks
+	}
+	if isSet(loginNetworkMasksValue) {
+		var loginNetworkMasks []string
+		if diags := loginNetworkMasksValue.ElementsAs(ctx, &loginNetworkMasks, false); diags.HasError() {
+			return fmt.Errorf("failed to read login_network_masks")
+		}
+		sort.Strings(loginNetworkMasks)
+		securityPreferenceQuery["LoginNetworkMasks"] = tea.String(strings.Join(loginNetworkMasks, ";"))
+	}
+
+	setAccountSecurity := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		if _, err := r.client.SetPasswordPolicyWithOptions(setPasswordPolicyRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+
+		if len(securityPreferenceQuery) > 0 {
+			setSecurityPreferenceRequest := &openapi.OpenApiRequest{
+				Query: securityPreferenceQuery,
+			}
+			if _, err := r.client.CallApi(ramSecurityPreferenceApiParams("SetSecurityPreference"), setSecurityPreferenceRequest, runtime); err != nil {
+				return handleAPIError(err)
+			}
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(setAccountSecurity, reconnectBackoff)
+}
+
+func (r *ramAccountSecurityResource) readAccountSecurity(ctx context.Context, state *ramAccountSecurityResourceModel) error {
+	var passwordPolicy *alicloudRamClient.GetPasswordPolicyResponseBodyPasswordPolicy
+	securityPreferenceResponse := &ramSecurityPreferenceResponse{}
+
+	readAccountSecurity := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		getPasswordPolicyResponse, err := r.client.GetPasswordPolicyWithOptions(runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		passwordPolicy = getPasswordPolicyResponse.Body.PasswordPolicy
+
+		body, err := r.client.CallApi(ramSecurityPreferenceApiParams("GetSecurityPreference"), &openapi.OpenApiRequest{}, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		if err := tea.Convert(body, securityPreferenceResponse); err != nil {
+			return backoff.Permanent(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(readAccountSecurity, reconnectBackoff); err != nil {
+		return err
+	}
+
+	state.MinimumPasswordLength = types.Int64Value(int64(tea.Int32Value(passwordPolicy.MinimumPasswordLength)))
+	state.RequireLowercaseCharacters = types.BoolValue(tea.BoolValue(passwordPolicy.RequireLowercaseCharacters))
+	state.RequireUppercaseCharacters = types.BoolValue(tea.BoolValue(passwordPolicy.RequireUppercaseCharacters))
+	state.RequireNumbers = types.BoolValue(tea.BoolValue(passwordPolicy.RequireNumbers))
+	state.RequireSymbols = types.BoolValue(tea.BoolValue(passwordPolicy.RequireSymbols))
+	state.MaxPasswordAge = types.Int64Value(int64(tea.Int32Value(passwordPolicy.MaxPasswordAge)))
+	state.PasswordReusePrevention = types.Int64Value(int64(tea.Int32Value(passwordPolicy.PasswordReusePrevention)))
+	state.MaxLoginAttempts = types.Int64Value(int64(tea.Int32Value(passwordPolicy.MaxLoginAttemps)))
+	state.HardExpiry = types.BoolValue(tea.BoolValue(passwordPolicy.HardExpiry))
+
+	securityPreference := securityPreferenceResponse.Body.SecurityPreference
+	if securityPreference == nil {
+		securityPreference = &ramSecurityPreference{}
+	}
+
+	loginNetworkMasks := []string{}
+	if loginProfilePreference := securityPreference.LoginProfilePreference; loginProfilePreference != nil {
+		state.EnforceMfaForLogin = types.BoolValue(tea.BoolValue(loginProfilePreference.EnforceMFAForLogin))
+		state.EnableSaveMfaTicket = types.BoolValue(tea.BoolValue(loginProfilePreference.EnableSaveMFATicket))
+		state.LoginSessionDuration = types.Int64Value(int64(tea.Int32Value(loginProfilePreference.LoginSessionDuration)))
+		state.AllowUserToChangePassword = types.BoolValue(tea.BoolValue(loginProfilePreference.AllowUserToChangePassword))
+		for _, mask := range strings.Split(tea.StringValue(loginProfilePreference.LoginNetworkMasks), ";") {
+			if mask = strings.TrimSpace(mask); mask != "" {
+				loginNetworkMasks = append(loginNetworkMasks, mask)
+			}
+		}
+	} else {
+		state.EnforceMfaForLogin = types.BoolValue(false)
+		state.EnableSaveMfaTicket = types.BoolValue(false)
+		state.LoginSessionDuration = types.Int64Null()
+		state.AllowUserToChangePassword = types.BoolValue(false)
+	}
+	loginNetworkMasksSet, diags := types.SetValueFrom(ctx, types.StringType, loginNetworkMasks)
+	if diags.HasError() {
+		return fmt.Errorf("failed to convert the login network masks")
+	}
+	state.LoginNetworkMasks = loginNetworkMasksSet
+
+	state.AllowUserToManageAccessKeys = types.BoolValue(securityPreference.AccessKeyPreference != nil &&
+		tea.BoolValue(securityPreference.AccessKeyPreference.AllowUserToManageAccessKeys))
+	state.AllowUserToManageMfaDevices = types.BoolValue(securityPreference.MFAPreference != nil &&
+		tea.BoolValue(securityPreference.MFAPreference.AllowUserToManageMFADevices))
+
+	return nil
+}
+
+func ramSecurityPreferenceApiParams(action string) *openapi.Params {
+	return &openapi.Params{
+		Action:      tea.String(action),
+		Version:     tea.String("2015-05-01"),
+		Protocol:    tea.String("HTTPS"),
+		Pathname:    tea.String("/"),
+		Method:      tea.String("POST"),
+		AuthType:    tea.String("AK"),
+		Style:       tea.String("RPC"),
+		ReqBodyType: tea.String("formData"),
+		BodyType:    tea.String("json"),
+	}
+}
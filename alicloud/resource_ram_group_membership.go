@@ -0,0 +1,327 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudRamClient "github.com/alibabacloud-go/ram-20150501/v2/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                = &ramGroupMembershipResource{}
+	_ resource.ResourceWithConfigure   = &ramGroupMembershipResource{}
+	_ resource.ResourceWithImportState = &ramGroupMembershipResource{}
+)
+
+func NewRamGroupMembershipResource() resource.Resource {
+	return &ramGroupMembershipResource{}
+}
+
+type ramGroupMembershipResource struct {
+	client *alicloudRamClient.Client
+}
+
+type ramGroupMembershipResourceModel struct {
+	GroupName types.String `tfsdk:"group_name"`
+	UserNames types.Set    `tfsdk:"user_names"`
+}
+
+func (r *ramGroupMembershipResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ram_group_membership"
+}
+
+func (r *ramGroupMembershipResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a RAM Group Membership resource that manages the full user set of a RAM group. " +
+			"Users that are added to the group outside of Terraform will be reported as drift and removed " +
+			"on the next apply. Do not use it together with the RAM user group attachment resource on the same group.",
+		Attributes: map[string]schema.Attribute{
+			"group_name": schema.StringAttribute{
+				Description: "The group name.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_names": schema.SetAttribute{
+				Description: "The usernames of all the RAM group members.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *ramGroupMembershipResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).ramClient
+}
+
+func (r *ramGroupMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *ramGroupMembershipResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var planUserNames []string
+	resp.Diagnostics.Append(plan.UserNames.ElementsAs(ctx, &planUserNames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The group may already have members, the membership is authoritative
+	// so the existing members that are not configured will be removed.
+	existingUserNames, err := listRamGroupUsers(r.client, plan.GroupName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read Users for Group",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := r.updateMembership(plan.GroupName.ValueString(), existingUserNames, planUserNames); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update Group Membership",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &ramGroupMembershipResourceModel{}
+	state.GroupName = plan.GroupName
+	state.UserNames = plan.UserNames
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramGroupMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *ramGroupMembershipResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userNames, err := listRamGroupUsers(r.client, state.GroupName.ValueString())
+	if err != nil {
+		if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == ERR_ENTITY_NOT_EXIST_GROUP {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read Users for Group",
+			err.Error(),
+		)
+		return
+	}
+
+	// The user names are null right after import, where every member is expected.
+	if !state.UserNames.IsNull() {
+		var stateUserNames []string
+		resp.Diagnostics.Append(state.UserNames.ElementsAs(ctx, &stateUserNames, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		_, addedUserNames := diffStringSlices(stateUserNames, userNames)
+		if len(addedUserNames) > 0 {
+			resp.Diagnostics.AddWarning(
+				"[API WARNING] Users Added to Group Outside of Terraform",
+				fmt.Sprintf("The following users were added to group %s outside of Terraform and will be "+
+					"removed on the next apply unless they are added to user_names: %s",
+					state.GroupName.ValueString(), strings.Join(addedUserNames, ", ")),
+			)
+		}
+	}
+
+	userNamesSet, diags := types.SetValueFrom(ctx, types.StringType, userNames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.UserNames = userNamesSet
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramGroupMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *ramGroupMembershipResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var planUserNames, stateUserNames []string
+	resp.Diagnostics.Append(plan.UserNames.ElementsAs(ctx, &planUserNames, false)...)
+	resp.Diagnostics.Append(state.UserNames.ElementsAs(ctx, &stateUserNames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateMembership(plan.GroupName.ValueString(), stateUserNames, planUserNames); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update Group Membership",
+			err.Error(),
+		)
+		return
+	}
+
+	state.GroupName = plan.GroupName
+	state.UserNames = plan.UserNames
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramGroupMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *ramGroupMembershipResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var stateUserNames []string
+	resp.Diagnostics.Append(state.UserNames.ElementsAs(ctx, &stateUserNames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateMembership(state.GroupName.ValueString(), stateUserNames, []string{}); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Remove Users from Group",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *ramGroupMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("group_name"), req, resp)
+}
+
+// updateMembership adds the users that are only in the new user names to the
+// group and removes the users that are only in the old user names from the group.
+func (r *ramGroupMembershipResource) updateMembership(groupName string, oldUserNames, newUserNames []string) error {
+	removedUserNames, addedUserNames := diffStringSlices(oldUserNames, newUserNames)
+
+	for _, userName := range addedUserNames {
+		addUserToGroupRequest := &alicloudRamClient.AddUserToGroupRequest{
+			UserName:  tea.String(userName),
+			GroupName: tea.String(groupName),
+		}
+
+		addUserToGroup := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			if _, err := r.client.AddUserToGroupWithOptions(addUserToGroupRequest, runtime); err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(addUserToGroup, reconnectBackoff); err != nil {
+			return fmt.Errorf("failed to add user %s to group %s: %w", userName, groupName, err)
+		}
+	}
+
+	for _, userName := range removedUserNames {
+		removeUserFromGroupRequest := &alicloudRamClient.RemoveUserFromGroupRequest{
+			UserName:  tea.String(userName),
+			GroupName: tea.String(groupName),
+		}
+
+		removeUserFromGroup := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			if _, err := r.client.RemoveUserFromGroupWithOptions(removeUserFromGroupRequest, runtime); err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(removeUserFromGroup, reconnectBackoff); err != nil {
+			return fmt.Errorf("failed to remove user %s from group %s: %w", userName, groupName, err)
+		}
+	}
+
+	return nil
+}
+
+// listRamGroupUsers returns the names of all the users of the group, paging
+// through ListUsersForGroup with Marker.
+func listRamGroupUsers(client *alicloudRamClient.Client, groupName string) (userNames []string, err error) {
+	listUsersForGroup := func() error {
+		runtime := &util.RuntimeOptions{}
+		userNames = []string{}
+
+		listUsersForGroupRequest := &alicloudRamClient.ListUsersForGroupRequest{
+			GroupName: tea.String(groupName),
+			MaxItems:  tea.Int32(1000),
+		}
+
+		for {
+			listUsersForGroupResponse, err := client.ListUsersForGroupWithOptions(listUsersForGroupRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+
+			if listUsersForGroupResponse.Body.Users != nil {
+				for _, user := range listUsersForGroupResponse.Body.Users.User {
+					userNames = append(userNames, tea.StringValue(user.UserName))
+				}
+			}
+
+			if !tea.BoolValue(listUsersForGroupResponse.Body.IsTruncated) {
+				break
+			}
+			listUsersForGroupRequest.Marker = listUsersForGroupResponse.Body.Marker
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(listUsersForGroup, reconnectBackoff)
+	sort.Strings(userNames)
+	return
+}
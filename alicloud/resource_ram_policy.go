@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -27,6 +29,7 @@ var (
 	_ resource.Resource                = &ramPolicyResource{}
 	_ resource.ResourceWithConfigure   = &ramPolicyResource{}
 	_ resource.ResourceWithImportState = &ramPolicyResource{}
+	_ resource.ResourceWithModifyPlan  = &ramPolicyResource{}
 )
 
 func NewRamPolicyResource() resource.Resource {
@@ -39,6 +42,8 @@ type ramPolicyResource struct {
 
 type ramPolicyResourceModel struct {
 	AttachedPolicies types.List   `tfsdk:"attached_policies"`
+	Description      types.String `tfsdk:"description"`
+	PolicyNamePrefix types.String `tfsdk:"policy_name_prefix"`
 	Policies         types.List   `tfsdk:"policies"`
 	UserName         types.String `tfsdk:"user_name"`
 }
@@ -61,6 +66,17 @@ func (r *ramPolicyResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Required:    true,
 				ElementType: types.StringType,
 			},
+			"description": schema.StringAttribute{
+				Description: "The description of the combined policies.",
+				Optional:    true,
+			},
+			"policy_name_prefix": schema.StringAttribute{
+				Description: "The prefix of the combined policy names. The combined policies " +
+					"are named as the prefix followed by a sequence number, e.g. devopsuser01-1. " +
+					"Default to user_name.",
+				Optional: true,
+				Computed: true,
+			},
 			"policies": schema.ListNestedAttribute{
 				Description: "A list of policies.",
 				Computed:    true,
@@ -100,6 +116,10 @@ func (r *ramPolicyResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	if plan.PolicyNamePrefix.IsUnknown() || plan.PolicyNamePrefix.IsNull() {
+		plan.PolicyNamePrefix = plan.UserName
+	}
+
 	policy, err := r.createPolicy(plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -111,6 +131,8 @@ func (r *ramPolicyResource) Create(ctx context.Context, req resource.CreateReque
 
 	state := &ramPolicyResourceModel{}
 	state.AttachedPolicies = plan.AttachedPolicies
+	state.Description = plan.Description
+	state.PolicyNamePrefix = plan.PolicyNamePrefix
 	state.Policies = types.ListValueMust(
 		types.ObjectType{
 			AttrTypes: map[string]attr.Type{
@@ -157,6 +179,12 @@ func (r *ramPolicyResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	// Combined policies created before policy_name_prefix was introduced are
+	// always named after the user.
+	if state.PolicyNamePrefix.IsNull() {
+		state.PolicyNamePrefix = state.UserName
+	}
+
 	listPoliciesForUser := func() error {
 		runtime := &util.RuntimeOptions{}
 
@@ -222,6 +250,10 @@ func (r *ramPolicyResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	if plan.PolicyNamePrefix.IsUnknown() || plan.PolicyNamePrefix.IsNull() {
+		plan.PolicyNamePrefix = plan.UserName
+	}
+
 	policy, err := r.createPolicy(plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -232,6 +264,8 @@ func (r *ramPolicyResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	state.AttachedPolicies = plan.AttachedPolicies
+	state.Description = plan.Description
+	state.PolicyNamePrefix = plan.PolicyNamePrefix
 	state.Policies = types.ListValueMust(
 		types.ObjectType{
 			AttrTypes: map[string]attr.Type{
@@ -280,51 +314,58 @@ func (r *ramPolicyResource) Delete(ctx context.Context, req resource.DeleteReque
 }
 
 func (r *ramPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	policyDetailsState := []*policyDetail{}
-	getPolicyResponse := &alicloudRamClient.GetPolicyResponse{}
-	policyNames := strings.Split(req.ID, ",")
-	var username string
-
-	var err error
-	getPolicy := func() error {
-		runtime := &util.RuntimeOptions{}
+	// The import ID is the principal in the format of user:<user_name>, with an
+	// optional :<policy_name_prefix> suffix when the prefix is not the user name.
+	importId := strings.Split(req.ID, ":")
+	if len(importId) < 2 || len(importId) > 3 || importId[0] != "user" || importId[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: user:<user_name> or "+
+				"user:<user_name>:<policy_name_prefix>. Got: %q", req.ID),
+		)
+		return
+	}
 
-		for _, policyName := range policyNames {
-			policyName = strings.ReplaceAll(policyName, " ", "")
+	username := importId[1]
+	policyNamePrefix := username
+	if len(importId) == 3 && importId[2] != "" {
+		policyNamePrefix = importId[2]
+	}
 
-			// Retrieves the policy document for the policy
-			getPolicyRequest := &alicloudRamClient.GetPolicyRequest{
-				PolicyName: tea.String(policyName),
-				PolicyType: tea.String("Custom"),
-			}
+	// Only the custom policies named as <policy_name_prefix>-<N> are managed
+	// by this resource, other policies attached to the user are ignored.
+	chunkNameRegex := regexp.MustCompile("^" + regexp.QuoteMeta(policyNamePrefix) + `-(\d+)$`)
+	chunkIndexes := make(map[string]int)
+	var chunkNames []string
+	var description string
 
-			getPolicyResponse, err = r.client.GetPolicyWithOptions(getPolicyRequest, runtime)
-			if err != nil {
-				handleAPIError(err)
-			}
+	listPoliciesForUser := func() error {
+		runtime := &util.RuntimeOptions{}
 
-			// Retrieves the name of the user attached to the policy.
-			listEntitiesForPolicy := &alicloudRamClient.ListEntitiesForPolicyRequest{
-				PolicyName: tea.String(policyName),
-				PolicyType: tea.String("Custom"),
-			}
+		listPoliciesForUserRequest := &alicloudRamClient.ListPoliciesForUserRequest{
+			UserName: tea.String(username),
+		}
 
-			getPolicyEntities, err := r.client.ListEntitiesForPolicyWithOptions(listEntitiesForPolicy, runtime)
-			if err != nil {
-				handleAPIError(err)
-			}
+		listPoliciesForUserResponse, err := r.client.ListPoliciesForUserWithOptions(listPoliciesForUserRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
 
-			if getPolicyResponse.Body.Policy != nil {
-				policyDetail := policyDetail{
-					PolicyName:     types.StringValue(*getPolicyResponse.Body.Policy.PolicyName),
-					PolicyDocument: types.StringValue(*getPolicyResponse.Body.DefaultPolicyVersion.PolicyDocument),
+		chunkNames = []string{}
+		if listPoliciesForUserResponse.Body.Policies != nil {
+			for _, policy := range listPoliciesForUserResponse.Body.Policies.Policy {
+				if tea.StringValue(policy.PolicyType) != "Custom" {
+					continue
 				}
-				policyDetailsState = append(policyDetailsState, &policyDetail)
-			}
-
-			if getPolicyEntities.Body.Users != nil {
-				for _, user := range getPolicyEntities.Body.Users.User {
-					username = *user.UserName
+				match := chunkNameRegex.FindStringSubmatch(tea.StringValue(policy.PolicyName))
+				if match == nil {
+					continue
+				}
+				index, _ := strconv.Atoi(match[1])
+				chunkIndexes[match[0]] = index
+				chunkNames = append(chunkNames, match[0])
+				if index == 1 {
+					description = tea.StringValue(policy.Description)
 				}
 			}
 		}
@@ -333,31 +374,102 @@ func (r *ramPolicyResource) ImportState(ctx context.Context, req resource.Import
 
 	reconnectBackoff := backoff.NewExponentialBackOff()
 	reconnectBackoff.MaxElapsedTime = 30 * time.Second
-	err = backoff.Retry(getPolicy, reconnectBackoff)
-	if err != nil {
+	if err := backoff.Retry(listPoliciesForUser, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to List Policies for User",
+			err.Error(),
+		)
 		return
 	}
 
-	var policyList []policyDetail
-	for _, policy := range policyDetailsState {
-		policies := policyDetail{
-			PolicyName:     types.StringValue(policy.PolicyName.ValueString()),
-			PolicyDocument: types.StringValue(policy.PolicyDocument.ValueString()),
-		}
+	if len(chunkNames) == 0 {
+		resp.Diagnostics.AddError(
+			"Combined Policies Not Found",
+			fmt.Sprintf("No custom policy named as %s-<N> is attached to the user %s. "+
+				"Use the import identifier user:<user_name>:<policy_name_prefix> if the "+
+				"combined policies were created with a different policy_name_prefix.", policyNamePrefix, username),
+		)
+		return
+	}
 
-		policyList = append(policyList, policies)
+	sort.Slice(chunkNames, func(i, j int) bool {
+		return chunkIndexes[chunkNames[i]] < chunkIndexes[chunkNames[j]]
+	})
+
+	policies := []attr.Value{}
+	for _, policyName := range chunkNames {
+		policies = append(policies, types.ObjectValueMust(
+			map[string]attr.Type{
+				"policy_name":     types.StringType,
+				"policy_document": types.StringType,
+			},
+			map[string]attr.Value{
+				"policy_name":     types.StringValue(policyName),
+				"policy_document": types.StringValue(""),
+			},
+		))
 	}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_name"), username)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("policies"), policyList)...)
+	state := &ramPolicyResourceModel{
+		// The source policies can not be derived from the combined policies,
+		// it will be set to the configured value on the next apply.
+		AttachedPolicies: types.ListValueMust(types.StringType, []attr.Value{}),
+		Description:      types.StringNull(),
+		PolicyNamePrefix: types.StringValue(policyNamePrefix),
+		Policies: types.ListValueMust(
+			types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"policy_name":     types.StringType,
+					"policy_document": types.StringType,
+				},
+			},
+			policies,
+		),
+		UserName: types.StringValue(username),
+	}
+	if description != "" {
+		state.Description = types.StringValue(description)
+	}
 
-	if !resp.Diagnostics.HasError() {
-		resp.Diagnostics.AddWarning(
-			"Unable to Set the attached_policies Attribute",
-			"After running terraform import, Terraform will not automatically set the attached_policies attributes."+
-				"To ensure that all attributes defined in the Terraform configuration are set, you need to run terraform apply."+
-				"This command will apply the changes and set the desired attributes according to your configuration.",
-		)
+	readPolicyDiags := r.readPolicy(state)
+	resp.Diagnostics.Append(readPolicyDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Unable to Set the attached_policies Attribute",
+		"The source policies of the combined policies can not be derived from AliCloud, "+
+			"so attached_policies is imported as an empty list. Run terraform apply to "+
+			"recreate the combined policies from the attached_policies in the configuration.",
+	)
+}
+
+func (r *ramPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// If the entire plan is null, the resource is planned for destruction.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config, plan *ramPolicyResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Default the policy name prefix to the user name, following the user name
+	// when it is changed.
+	if config.PolicyNamePrefix.IsNull() && !plan.UserName.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("policy_name_prefix"), plan.UserName)...)
 	}
 }
 
@@ -371,12 +483,15 @@ func (r *ramPolicyResource) createPolicy(plan *ramPolicyResourceModel) (policies
 		runtime := &util.RuntimeOptions{}
 
 		for i, policy := range formattedPolicy {
-			policyName := plan.UserName.ValueString() + "-" + strconv.Itoa(i+1)
+			policyName := plan.PolicyNamePrefix.ValueString() + "-" + strconv.Itoa(i+1)
 
 			createPolicyRequest := &alicloudRamClient.CreatePolicyRequest{
 				PolicyName:     tea.String(policyName),
 				PolicyDocument: tea.String(policy),
 			}
+			if !(plan.Description.IsNull() || plan.Description.IsUnknown()) {
+				createPolicyRequest.Description = tea.String(plan.Description.ValueString())
+			}
 
 			if _, err := r.client.CreatePolicyWithOptions(createPolicyRequest, runtime); err != nil {
 				handleAPIError(err)
@@ -387,7 +502,7 @@ func (r *ramPolicyResource) createPolicy(plan *ramPolicyResourceModel) (policies
 	}
 
 	for i, policies := range formattedPolicy {
-		policyName := plan.UserName.ValueString() + "-" + strconv.Itoa(i+1)
+		policyName := plan.PolicyNamePrefix.ValueString() + "-" + strconv.Itoa(i+1)
 
 		policyObj := types.ObjectValueMust(
 			map[string]attr.Type{
@@ -0,0 +1,669 @@
+package alicloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	alicloudRamClient "github.com/alibabacloud-go/ram-20150501/v2/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                = &ramRoleResource{}
+	_ resource.ResourceWithConfigure   = &ramRoleResource{}
+	_ resource.ResourceWithImportState = &ramRoleResource{}
+
+	_ basetypes.StringTypable                    = ramPolicyDocumentType{}
+	_ basetypes.StringValuableWithSemanticEquals = ramPolicyDocumentValue{}
+)
+
+func NewRamRoleResource() resource.Resource {
+	return &ramRoleResource{}
+}
+
+type ramRoleResource struct {
+	client *alicloudRamClient.Client
+}
+
+type ramRoleResourceModel struct {
+	RoleName                 types.String           `tfsdk:"role_name"`
+	AssumeRolePolicyDocument ramPolicyDocumentValue `tfsdk:"assume_role_policy_document"`
+	Description              types.String           `tfsdk:"description"`
+	MaxSessionDuration       types.Int64            `tfsdk:"max_session_duration"`
+	SystemPolicies           types.Set              `tfsdk:"system_policies"`
+	CustomPolicies           types.Set              `tfsdk:"custom_policies"`
+	RoleId                   types.String           `tfsdk:"role_id"`
+	Arn                      types.String           `tfsdk:"arn"`
+	CreateDate               types.String           `tfsdk:"create_date"`
+}
+
+func (r *ramRoleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ram_role"
+}
+
+func (r *ramRoleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a RAM Role resource that manages the trust policy of the role and " +
+			"the full set of the policies attached to the role.",
+		Attributes: map[string]schema.Attribute{
+			"role_name": schema.StringAttribute{
+				Description: "The name of the RAM role.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"assume_role_policy_document": schema.StringAttribute{
+				Description: "The trust policy document in JSON format that specifies who can assume the role. " +
+					"It is compared semantically, so the document returned by the API does not cause a diff when it is " +
+					"formatted or ordered differently.",
+				Required:   true,
+				CustomType: ramPolicyDocumentType{},
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the RAM role.",
+				Optional:    true,
+			},
+			"max_session_duration": schema.Int64Attribute{
+				Description: "The maximum session duration of the RAM role in seconds. Valid values: 3600 to 43200, default to 3600.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(3600, 43200),
+				},
+				Default: int64default.StaticInt64(3600),
+			},
+			"system_policies": schema.SetAttribute{
+				Description: "The system policies attached to the RAM role. The system policies attached outside " +
+					"of Terraform will be detached.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+			},
+			"custom_policies": schema.SetAttribute{
+				Description: "The custom policies attached to the RAM role. The custom policies attached outside " +
+					"of Terraform will be detached.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+			},
+			"role_id": schema.StringAttribute{
+				Description: "The ID of the RAM role.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"arn": schema.StringAttribute{
+				Description: "The ARN of the RAM role.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"create_date": schema.StringAttribute{
+				Description: "The create date of the RAM role.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ramRoleResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).ramClient
+}
+
+func (r *ramRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *ramRoleResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var role *alicloudRamClient.CreateRoleResponseBodyRole
+	createRole := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		createRoleRequest := &alicloudRamClient.CreateRoleRequest{
+			RoleName:                 tea.String(plan.RoleName.ValueString()),
+			AssumeRolePolicyDocument: tea.String(plan.AssumeRolePolicyDocument.ValueString()),
+			MaxSessionDuration:       tea.Int64(plan.MaxSessionDuration.ValueInt64()),
+		}
+		if !plan.Description.IsNull() {
+			createRoleRequest.Description = tea.String(plan.Description.ValueString())
+		}
+
+		createRoleResponse, err := r.client.CreateRoleWithOptions(createRoleRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		role = createRoleResponse.Body.Role
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(createRole, reconnectBackoff)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Create Role",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &ramRoleResourceModel{
+		RoleName:                 plan.RoleName,
+		AssumeRolePolicyDocument: plan.AssumeRolePolicyDocument,
+		Description:              plan.Description,
+		MaxSessionDuration:       plan.MaxSessionDuration,
+		SystemPolicies:           types.SetValueMust(types.StringType, []attr.Value{}),
+		CustomPolicies:           types.SetValueMust(types.StringType, []attr.Value{}),
+		RoleId:                   types.StringValue(tea.StringValue(role.RoleId)),
+		Arn:                      types.StringValue(tea.StringValue(role.Arn)),
+		CreateDate:               types.StringValue(tea.StringValue(role.CreateDate)),
+	}
+
+	// Save the role to the state first, so that it is not orphaned when the
+	// policies fail to be attached.
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateRolePolicies(ctx, state, plan); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Attach Policies to Role",
+			err.Error(),
+		)
+	}
+
+	setStateDiags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *ramRoleResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var role *alicloudRamClient.GetRoleResponseBodyRole
+	getRole := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		getRoleRequest := &alicloudRamClient.GetRoleRequest{
+			RoleName: tea.String(state.RoleName.ValueString()),
+		}
+
+		getRoleResponse, err := r.client.GetRoleWithOptions(getRoleRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		role = getRoleResponse.Body.Role
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(getRole, reconnectBackoff)
+	if err != nil {
+		if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == ERR_ENTITY_NOT_EXIST_ROLE {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read Role",
+			err.Error(),
+		)
+		return
+	}
+
+	// The configured trust policy document is kept by the semantic equality
+	// of the type if it is equal to the document returned by the API.
+	state.AssumeRolePolicyDocument = ramPolicyDocumentValue{
+		StringValue: types.StringValue(tea.StringValue(role.AssumeRolePolicyDocument)),
+	}
+	if description := tea.StringValue(role.Description); description != "" || !state.Description.IsNull() {
+		state.Description = types.StringValue(description)
+	}
+	state.MaxSessionDuration = types.Int64Value(tea.Int64Value(role.MaxSessionDuration))
+	state.RoleId = types.StringValue(tea.StringValue(role.RoleId))
+	state.Arn = types.StringValue(tea.StringValue(role.Arn))
+	state.CreateDate = types.StringValue(tea.StringValue(role.CreateDate))
+
+	systemPolicies, customPolicies, err := r.listRolePolicies(state.RoleName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read Policies for Role",
+			err.Error(),
+		)
+		return
+	}
+
+	systemPoliciesSet, diags := types.SetValueFrom(ctx, types.StringType, systemPolicies)
+	resp.Diagnostics.Append(diags...)
+	customPoliciesSet, diags := types.SetValueFrom(ctx, types.StringType, customPolicies)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.SystemPolicies = systemPoliciesSet
+	state.CustomPolicies = customPoliciesSet
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *ramRoleResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !ramPolicyJsonEqual(plan.AssumeRolePolicyDocument.ValueString(), state.AssumeRolePolicyDocument.ValueString()) ||
+		!plan.Description.Equal(state.Description) ||
+		!plan.MaxSessionDuration.Equal(state.MaxSessionDuration) {
+		updateRole := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			updateRoleRequest := &alicloudRamClient.UpdateRoleRequest{
+				RoleName:                    tea.String(plan.RoleName.ValueString()),
+				NewAssumeRolePolicyDocument: tea.String(plan.AssumeRolePolicyDocument.ValueString()),
+				NewDescription:              tea.String(plan.Description.ValueString()),
+				NewMaxSessionDuration:       tea.Int64(plan.MaxSessionDuration.ValueInt64()),
+			}
+
+			if _, err := r.client.UpdateRoleWithOptions(updateRoleRequest, runtime); err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		err := backoff.Retry(updateRole, reconnectBackoff)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Update Role",
+				err.Error(),
+			)
+			return
+		}
+
+		state.AssumeRolePolicyDocument = plan.AssumeRolePolicyDocument
+		state.Description = plan.Description
+		state.MaxSessionDuration = plan.MaxSessionDuration
+	}
+
+	if err := r.updateRolePolicies(ctx, state, plan); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update Policies for Role",
+			err.Error(),
+		)
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *ramRoleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The policies must be detached before the role is deleted.
+	noPolicies := &ramRoleResourceModel{
+		SystemPolicies: types.SetValueMust(types.StringType, []attr.Value{}),
+		CustomPolicies: types.SetValueMust(types.StringType, []attr.Value{}),
+	}
+	if err := r.updateRolePolicies(ctx, state, noPolicies); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Detach Policies from Role",
+			err.Error(),
+		)
+		return
+	}
+
+	deleteRole := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		deleteRoleRequest := &alicloudRamClient.DeleteRoleRequest{
+			RoleName: tea.String(state.RoleName.ValueString()),
+		}
+
+		if _, err := r.client.DeleteRoleWithOptions(deleteRoleRequest, runtime); err != nil {
+			if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == ERR_ENTITY_NOT_EXIST_ROLE {
+				return nil
+			}
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(deleteRole, reconnectBackoff)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Delete Role",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *ramRoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("role_name"), req, resp)
+}
+
+// updateRolePolicies attaches and detaches the policies of the role to match
+// the plan, and updates the policies in the state as they are changed.
+func (r *ramRoleResource) updateRolePolicies(ctx context.Context, state, plan *ramRoleResourceModel) error {
+	for _, policyType := range []string{"System", "Custom"} {
+		statePolicies, planPolicies := state.SystemPolicies, plan.SystemPolicies
+		if policyType == "Custom" {
+			statePolicies, planPolicies = state.CustomPolicies, plan.CustomPolicies
+		}
+
+		var oldPolicyNames, newPolicyNames []string
+		if diags := statePolicies.ElementsAs(ctx, &oldPolicyNames, false); diags.HasError() {
+			return fmt.Errorf("failed to read the %s policies from the state", policyType)
+		}
+		if diags := planPolicies.ElementsAs(ctx, &newPolicyNames, false); diags.HasError() {
+			return fmt.Errorf("failed to read the %s policies from the plan", policyType)
+		}
+
+		removedPolicyNames, addedPolicyNames := diffStringSlices(oldPolicyNames, newPolicyNames)
+		currentPolicyNames := map[string]bool{}
+		for _, policyName := range oldPolicyNames {
+			currentPolicyNames[policyName] = true
+		}
+
+		var err error
+		for _, policyName := range removedPolicyNames {
+			if err = r.detachPolicyFromRole(state.RoleName.ValueString(), policyName, policyType); err != nil {
+				break
+			}
+			delete(currentPolicyNames, policyName)
+		}
+		if err == nil {
+			for _, policyName := range addedPolicyNames {
+				if err = r.attachPolicyToRole(state.RoleName.ValueString(), policyName, policyType); err != nil {
+					break
+				}
+				currentPolicyNames[policyName] = true
+			}
+		}
+
+		policyNames := []string{}
+		for policyName := range currentPolicyNames {
+			policyNames = append(policyNames, policyName)
+		}
+		sort.Strings(policyNames)
+		policyNamesSet := types.SetValueMust(types.StringType, []attr.Value{})
+		if len(policyNames) > 0 {
+			policyNamesSet, _ = types.SetValueFrom(ctx, types.StringType, policyNames)
+		}
+		if policyType == "System" {
+			state.SystemPolicies = policyNamesSet
+		} else {
+			state.CustomPolicies = policyNamesSet
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ramRoleResource) attachPolicyToRole(roleName, policyName, policyType string) error {
+	attachPolicyToRole := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		attachPolicyToRoleRequest := &alicloudRamClient.AttachPolicyToRoleRequest{
+			RoleName:   tea.String(roleName),
+			PolicyName: tea.String(policyName),
+			PolicyType: tea.String(policyType),
+		}
+
+		if _, err := r.client.AttachPolicyToRoleWithOptions(attachPolicyToRoleRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(attachPolicyToRole, reconnectBackoff); err != nil {
+		return fmt.Errorf("failed to attach %s policy %s: %w", policyType, policyName, err)
+	}
+	return nil
+}
+
+func (r *ramRoleResource) detachPolicyFromRole(roleName, policyName, policyType string) error {
+	detachPolicyFromRole := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		detachPolicyFromRoleRequest := &alicloudRamClient.DetachPolicyFromRoleRequest{
+			RoleName:   tea.String(roleName),
+			PolicyName: tea.String(policyName),
+			PolicyType: tea.String(policyType),
+		}
+
+		if _, err := r.client.DetachPolicyFromRoleWithOptions(detachPolicyFromRoleRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(detachPolicyFromRole, reconnectBackoff); err != nil {
+		return fmt.Errorf("failed to detach %s policy %s: %w", policyType, policyName, err)
+	}
+	return nil
+}
+
+// listRolePolicies returns the names of the system and custom policies attached to the role.
+func (r *ramRoleResource) listRolePolicies(roleName string) (systemPolicies, customPolicies []string, err error) {
+	listPoliciesForRole := func() error {
+		runtime := &util.RuntimeOptions{}
+		systemPolicies = []string{}
+		customPolicies = []string{}
+
+		listPoliciesForRoleRequest := &alicloudRamClient.ListPoliciesForRoleRequest{
+			RoleName: tea.String(roleName),
+		}
+
+		listPoliciesForRoleResponse, err := r.client.ListPoliciesForRoleWithOptions(listPoliciesForRoleRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+
+		if listPoliciesForRoleResponse.Body.Policies != nil {
+			for _, policy := range listPoliciesForRoleResponse.Body.Policies.Policy {
+				if tea.StringValue(policy.PolicyType) == "System" {
+					systemPolicies = append(systemPolicies, tea.StringValue(policy.PolicyName))
+				} else {
+					customPolicies = append(customPolicies, tea.StringValue(policy.PolicyName))
+				}
+			}
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(listPoliciesForRole, reconnectBackoff)
+	return
+}
+
+// ramPolicyDocumentType is the string type of the policy documents, whose
+// values are compared semantically.
+type ramPolicyDocumentType struct {
+	basetypes.StringType
+}
+
+func (t ramPolicyDocumentType) Equal(o attr.Type) bool {
+	other, ok := o.(ramPolicyDocumentType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t ramPolicyDocumentType) String() string {
+	return "ramPolicyDocumentType"
+}
+
+func (t ramPolicyDocumentType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return ramPolicyDocumentValue{StringValue: in}, nil
+}
+
+func (t ramPolicyDocumentType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return ramPolicyDocumentValue{StringValue: stringValue}, nil
+}
+
+func (t ramPolicyDocumentType) ValueType(_ context.Context) attr.Value {
+	return ramPolicyDocumentValue{}
+}
+
+// ramPolicyDocumentValue is the value of the policy documents. It is
+// semantically equal to another document regardless of the formatting and
+// the order of the elements, so that the document returned by the API does
+// not replace the configured one.
+type ramPolicyDocumentValue struct {
+	basetypes.StringValue
+}
+
+func (v ramPolicyDocumentValue) Equal(o attr.Value) bool {
+	other, ok := o.(ramPolicyDocumentValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v ramPolicyDocumentValue) Type(_ context.Context) attr.Type {
+	return ramPolicyDocumentType{}
+}
+
+func (v ramPolicyDocumentValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	newValue, ok := newValuable.(ramPolicyDocumentValue)
+	if !ok {
+		return false, nil
+	}
+	return ramPolicyJsonEqual(v.ValueString(), newValue.ValueString()), nil
+}
+
+// ramPolicyJsonEqual returns whether the two policy documents are semantically
+// equal, regardless of the formatting, the order of the string lists and
+// whether a single value is written as a string or a list.
+func ramPolicyJsonEqual(a, b string) bool {
+	var aValue, bValue interface{}
+	if err := json.Unmarshal([]byte(a), &aValue); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(b), &bValue); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(normalizeRamPolicyJson(aValue), normalizeRamPolicyJson(bValue))
+}
+
+func normalizeRamPolicyJson(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		normalized := map[string]interface{}{}
+		for key, element := range v {
+			normalized[key] = normalizeRamPolicyJson(element)
+		}
+		return normalized
+	case []interface{}:
+		strs := []string{}
+		for _, element := range v {
+			str, ok := element.(string)
+			if !ok {
+				break
+			}
+			strs = append(strs, str)
+		}
+		// Only the string lists are unordered, e.g. actions and resources.
+		if len(strs) == len(v) {
+			if len(strs) == 1 {
+				return strs[0]
+			}
+			sort.Strings(strs)
+			return strs
+		}
+
+		normalized := []interface{}{}
+		for _, element := range v {
+			normalized = append(normalized, normalizeRamPolicyJson(element))
+		}
+		if len(normalized) == 1 {
+			return normalized[0]
+		}
+		return normalized
+	default:
+		return v
+	}
+}
@@ -0,0 +1,705 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudRamClient "github.com/alibabacloud-go/ram-20150501/v2/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                   = &ramUserResource{}
+	_ resource.ResourceWithConfigure      = &ramUserResource{}
+	_ resource.ResourceWithImportState    = &ramUserResource{}
+	_ resource.ResourceWithValidateConfig = &ramUserResource{}
+)
+
+func NewRamUserResource() resource.Resource {
+	return &ramUserResource{}
+}
+
+type ramUserResource struct {
+	client *alicloudRamClient.Client
+}
+
+type ramUserResourceModel struct {
+	UserName              types.String              `tfsdk:"user_name"`
+	DisplayName           types.String              `tfsdk:"display_name"`
+	Email                 types.String              `tfsdk:"email"`
+	MobilePhone           types.String              `tfsdk:"mobile_phone"`
+	Comments              types.String              `tfsdk:"comments"`
+	LoginProfile          *ramUserLoginProfileModel `tfsdk:"login_profile"`
+	UserId                types.String              `tfsdk:"user_id"`
+	CreateDate            types.String              `tfsdk:"create_date"`
+	LastLoginDate         types.String              `tfsdk:"last_login_date"`
+	VirtualMfaDeviceBound types.Bool                `tfsdk:"virtual_mfa_device_bound"`
+}
+
+type ramUserLoginProfileModel struct {
+	Password              types.String `tfsdk:"password"`
+	PasswordResetRequired types.Bool   `tfsdk:"password_reset_required"`
+	MfaBindRequired       types.Bool   `tfsdk:"mfa_bind_required"`
+}
+
+func (r *ramUserResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ram_user"
+}
+
+func (r *ramUserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a RAM User resource with an optional console login profile. Destroying the user " +
+			"detaches its policies, removes it from its groups and deletes its access keys first.",
+		Attributes: map[string]schema.Attribute{
+			"user_name": schema.StringAttribute{
+				Description: "The name of the RAM user. Changing the name renames the user in place.",
+				Required:    true,
+			},
+			"display_name": schema.StringAttribute{
+				Description: "The display name of the RAM user.",
+				Optional:    true,
+			},
+			"email": schema.StringAttribute{
+				Description: "The email address of the RAM user.",
+				Optional:    true,
+			},
+			"mobile_phone": schema.StringAttribute{
+				Description: "The mobile phone number of the RAM user, in the format of <country code>-<number>, e.g. 86-18600008888.",
+				Optional:    true,
+			},
+			"comments": schema.StringAttribute{
+				Description: "The comments of the RAM user.",
+				Optional:    true,
+			},
+			"user_id": schema.StringAttribute{
+				Description: "The ID of the RAM user.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"create_date": schema.StringAttribute{
+				Description: "The create date of the RAM user.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_login_date": schema.StringAttribute{
+				Description: "The date when the RAM user last logged on to the console.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"virtual_mfa_device_bound": schema.BoolAttribute{
+				Description: "Whether a virtual MFA device is bound to the RAM user.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"login_profile": schema.SingleNestedBlock{
+				Description: "The console login profile of the RAM user. Removing the block deletes the login profile.",
+				Attributes: map[string]schema.Attribute{
+					"password": schema.StringAttribute{
+						Description: "The console login password of the RAM user. The password can not be read back, " +
+							"so it is reset to the configured value on the first apply after import.",
+						Optional:  true,
+						Sensitive: true,
+					},
+					"password_reset_required": schema.BoolAttribute{
+						Description: "Whether the RAM user must reset the password at the next logon. Default to false.",
+						Optional:    true,
+					},
+					"mfa_bind_required": schema.BoolAttribute{
+						Description: "Whether the RAM user must bind an MFA device at the next logon. Default to false.",
+						Optional:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ramUserResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).ramClient
+}
+
+func (r *ramUserResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *ramUserResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.LoginProfile != nil && config.LoginProfile.Password.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("login_profile").AtName("password"),
+			"[Input Error] Missing Login Password",
+			"The password must be configured when the login_profile block is configured.",
+		)
+	}
+}
+
+func (r *ramUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *ramUserResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var user *alicloudRamClient.CreateUserResponseBodyUser
+	createUser := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		createUserRequest := &alicloudRamClient.CreateUserRequest{
+			UserName: tea.String(plan.UserName.ValueString()),
+		}
+		if !plan.DisplayName.IsNull() {
+			createUserRequest.DisplayName = tea.String(plan.DisplayName.ValueString())
+		}
+		if !plan.Email.IsNull() {
+			createUserRequest.Email = tea.String(plan.Email.ValueString())
+		}
+		if !plan.MobilePhone.IsNull() {
+			createUserRequest.MobilePhone = tea.String(plan.MobilePhone.ValueString())
+		}
+		if !plan.Comments.IsNull() {
+			createUserRequest.Comments = tea.String(plan.Comments.ValueString())
+		}
+
+		createUserResponse, err := r.client.CreateUserWithOptions(createUserRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		user = createUserResponse.Body.User
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(createUser, reconnectBackoff)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Create User",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &ramUserResourceModel{
+		UserName:              plan.UserName,
+		DisplayName:           plan.DisplayName,
+		Email:                 plan.Email,
+		MobilePhone:           plan.MobilePhone,
+		Comments:              plan.Comments,
+		UserId:                types.StringValue(tea.StringValue(user.UserId)),
+		CreateDate:            types.StringValue(tea.StringValue(user.CreateDate)),
+		LastLoginDate:         types.StringValue(""),
+		VirtualMfaDeviceBound: types.BoolValue(false),
+	}
+
+	// Save the user to the state first, so that it is not orphaned when the
+	// login profile fails to be created.
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.LoginProfile != nil {
+		if err := r.createLoginProfile(plan.UserName.ValueString(), plan.LoginProfile); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Create Login Profile for User",
+				err.Error(),
+			)
+			return
+		}
+		state.LoginProfile = plan.LoginProfile
+	}
+
+	setStateDiags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *ramUserResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var user *alicloudRamClient.GetUserResponseBodyUser
+	getUser := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		getUserRequest := &alicloudRamClient.GetUserRequest{
+			UserName: tea.String(state.UserName.ValueString()),
+		}
+
+		getUserResponse, err := r.client.GetUserWithOptions(getUserRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		user = getUserResponse.Body.User
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(getUser, reconnectBackoff)
+	if err != nil {
+		if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == ERR_ENTITY_NOT_EXIST_USER {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read User",
+			err.Error(),
+		)
+		return
+	}
+
+	// Keep the optional attributes null when they are not configured and
+	// not set on the user.
+	refreshString := func(current types.String, value *string) types.String {
+		if tea.StringValue(value) == "" && current.IsNull() {
+			return current
+		}
+		return types.StringValue(tea.StringValue(value))
+	}
+	state.UserName = types.StringValue(tea.StringValue(user.UserName))
+	state.DisplayName = refreshString(state.DisplayName, user.DisplayName)
+	state.Email = refreshString(state.Email, user.Email)
+	state.MobilePhone = refreshString(state.MobilePhone, user.MobilePhone)
+	state.Comments = refreshString(state.Comments, user.Comments)
+	state.UserId = types.StringValue(tea.StringValue(user.UserId))
+	state.CreateDate = types.StringValue(tea.StringValue(user.CreateDate))
+	state.LastLoginDate = types.StringValue(tea.StringValue(user.LastLoginDate))
+
+	loginProfile, err := r.getLoginProfile(state.UserName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read Login Profile for User",
+			err.Error(),
+		)
+		return
+	}
+	if loginProfile == nil {
+		state.LoginProfile = nil
+	} else {
+		// The password can not be read back, so it is kept from the state.
+		if state.LoginProfile == nil {
+			state.LoginProfile = &ramUserLoginProfileModel{
+				Password:              types.StringNull(),
+				PasswordResetRequired: types.BoolNull(),
+				MfaBindRequired:       types.BoolNull(),
+			}
+		}
+		refreshBool := func(current types.Bool, value *bool) types.Bool {
+			if !tea.BoolValue(value) && current.IsNull() {
+				return current
+			}
+			return types.BoolValue(tea.BoolValue(value))
+		}
+		state.LoginProfile.PasswordResetRequired = refreshBool(state.LoginProfile.PasswordResetRequired, loginProfile.PasswordResetRequired)
+		state.LoginProfile.MfaBindRequired = refreshBool(state.LoginProfile.MfaBindRequired, loginProfile.MFABindRequired)
+	}
+
+	mfaDevice, err := r.getMfaDevice(state.UserName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read MFA Device for User",
+			err.Error(),
+		)
+		return
+	}
+	state.VirtualMfaDeviceBound = types.BoolValue(mfaDevice != nil && tea.StringValue(mfaDevice.Type) == "VMFA")
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *ramUserResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.UserName.Equal(state.UserName) ||
+		!plan.DisplayName.Equal(state.DisplayName) ||
+		!plan.Email.Equal(state.Email) ||
+		!plan.MobilePhone.Equal(state.MobilePhone) ||
+		!plan.Comments.Equal(state.Comments) {
+		updateUser := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			updateUserRequest := &alicloudRamClient.UpdateUserRequest{
+				UserName:       tea.String(state.UserName.ValueString()),
+				NewDisplayName: tea.String(plan.DisplayName.ValueString()),
+				NewEmail:       tea.String(plan.Email.ValueString()),
+				NewMobilePhone: tea.String(plan.MobilePhone.ValueString()),
+				NewComments:    tea.String(plan.Comments.ValueString()),
+			}
+			if !plan.UserName.Equal(state.UserName) {
+				updateUserRequest.NewUserName = tea.String(plan.UserName.ValueString())
+			}
+
+			if _, err := r.client.UpdateUserWithOptions(updateUserRequest, runtime); err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		err := backoff.Retry(updateUser, reconnectBackoff)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Update User",
+				err.Error(),
+			)
+			return
+		}
+
+		state.UserName = plan.UserName
+		state.DisplayName = plan.DisplayName
+		state.Email = plan.Email
+		state.MobilePhone = plan.MobilePhone
+		state.Comments = plan.Comments
+	}
+
+	userName := plan.UserName.ValueString()
+	var err error
+	switch {
+	case plan.LoginProfile == nil && state.LoginProfile != nil:
+		err = r.deleteLoginProfile(userName)
+	case plan.LoginProfile != nil && state.LoginProfile == nil:
+		err = r.createLoginProfile(userName, plan.LoginProfile)
+	case plan.LoginProfile != nil && state.LoginProfile != nil:
+		if !plan.LoginProfile.Password.Equal(state.LoginProfile.Password) ||
+			!plan.LoginProfile.PasswordResetRequired.Equal(state.LoginProfile.PasswordResetRequired) ||
+			!plan.LoginProfile.MfaBindRequired.Equal(state.LoginProfile.MfaBindRequired) {
+			err = r.updateLoginProfile(userName, state.LoginProfile, plan.LoginProfile)
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update Login Profile for User",
+			err.Error(),
+		)
+	} else {
+		state.LoginProfile = plan.LoginProfile
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *ramUserResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The user can not be deleted while it still has policies, groups, access
+	// keys, a login profile or an MFA device.
+	if err := r.deleteUserDependencies(state.UserName.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Clean Up User",
+			err.Error(),
+		)
+		return
+	}
+
+	deleteUser := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		deleteUserRequest := &alicloudRamClient.DeleteUserRequest{
+			UserName: tea.String(state.UserName.ValueString()),
+		}
+
+		if _, err := r.client.DeleteUserWithOptions(deleteUserRequest, runtime); err != nil {
+			if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == ERR_ENTITY_NOT_EXIST_USER {
+				return nil
+			}
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(deleteUser, reconnectBackoff)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Delete User",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *ramUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("user_name"), req, resp)
+}
+
+func (r *ramUserResource) createLoginProfile(userName string, loginProfile *ramUserLoginProfileModel) error {
+	createLoginProfile := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		createLoginProfileRequest := &alicloudRamClient.CreateLoginProfileRequest{
+			UserName:              tea.String(userName),
+			Password:              tea.String(loginProfile.Password.ValueString()),
+			PasswordResetRequired: tea.Bool(loginProfile.PasswordResetRequired.ValueBool()),
+			MFABindRequired:       tea.Bool(loginProfile.MfaBindRequired.ValueBool()),
+		}
+
+		if _, err := r.client.CreateLoginProfileWithOptions(createLoginProfileRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(createLoginProfile, reconnectBackoff)
+}
+
+// updateLoginProfile updates the login profile of the user, the password is
+// only sent when it is changed so that it is not reset unintentionally.
+func (r *ramUserResource) updateLoginProfile(userName string, oldLoginProfile, newLoginProfile *ramUserLoginProfileModel) error {
+	updateLoginProfile := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		updateLoginProfileRequest := &alicloudRamClient.UpdateLoginProfileRequest{
+			UserName:              tea.String(userName),
+			PasswordResetRequired: tea.Bool(newLoginProfile.PasswordResetRequired.ValueBool()),
+			MFABindRequired:       tea.Bool(newLoginProfile.MfaBindRequired.ValueBool()),
+		}
+		if !newLoginProfile.Password.Equal(oldLoginProfile.Password) {
+			updateLoginProfileRequest.Password = tea.String(newLoginProfile.Password.ValueString())
+		}
+
+		if _, err := r.client.UpdateLoginProfileWithOptions(updateLoginProfileRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(updateLoginProfile, reconnectBackoff)
+}
+
+// deleteLoginProfile deletes the login profile of the user, the login profile
+// that is already deleted is ignored.
+func (r *ramUserResource) deleteLoginProfile(userName string) error {
+	deleteLoginProfile := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		deleteLoginProfileRequest := &alicloudRamClient.DeleteLoginProfileRequest{
+			UserName: tea.String(userName),
+		}
+
+		if _, err := r.client.DeleteLoginProfileWithOptions(deleteLoginProfileRequest, runtime); err != nil {
+			if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == ERR_ENTITY_NOT_EXIST_LOGIN_PROFILE {
+				return nil
+			}
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(deleteLoginProfile, reconnectBackoff)
+}
+
+// getLoginProfile returns the login profile of the user, or nil if the user
+// has no login profile.
+func (r *ramUserResource) getLoginProfile(userName string) (loginProfile *alicloudRamClient.GetLoginProfileResponseBodyLoginProfile, err error) {
+	getLoginProfile := func() error {
+		runtime := &util.RuntimeOptions{}
+		loginProfile = nil
+
+		getLoginProfileRequest := &alicloudRamClient.GetLoginProfileRequest{
+			UserName: tea.String(userName),
+		}
+
+		getLoginProfileResponse, err := r.client.GetLoginProfileWithOptions(getLoginProfileRequest, runtime)
+		if err != nil {
+			if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == ERR_ENTITY_NOT_EXIST_LOGIN_PROFILE {
+				return nil
+			}
+			return handleAPIError(err)
+		}
+		loginProfile = getLoginProfileResponse.Body.LoginProfile
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(getLoginProfile, reconnectBackoff)
+	return
+}
+
+// getMfaDevice returns the MFA device bound to the user, or nil if no MFA
+// device is bound.
+func (r *ramUserResource) getMfaDevice(userName string) (mfaDevice *alicloudRamClient.GetUserMFAInfoResponseBodyMFADevice, err error) {
+	getUserMfaInfo := func() error {
+		runtime := &util.RuntimeOptions{}
+		mfaDevice = nil
+
+		getUserMfaInfoRequest := &alicloudRamClient.GetUserMFAInfoRequest{
+			UserName: tea.String(userName),
+		}
+
+		getUserMfaInfoResponse, err := r.client.GetUserMFAInfoWithOptions(getUserMfaInfoRequest, runtime)
+		if err != nil {
+			if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == ERR_ENTITY_NOT_EXIST_MFA_DEVICE {
+				return nil
+			}
+			return handleAPIError(err)
+		}
+		mfaDevice = getUserMfaInfoResponse.Body.MFADevice
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(getUserMfaInfo, reconnectBackoff)
+	return
+}
+
+// deleteUserDependencies deletes the access keys, the login profile and the
+// MFA device binding of the user, removes the user from all its groups and
+// detaches all the policies from the user.
+func (r *ramUserResource) deleteUserDependencies(userName string) error {
+	accessKeyResource := &ramAccessKeyResource{client: r.client}
+	accessKeys, err := accessKeyResource.listAccessKeys(userName)
+	if err != nil {
+		return fmt.Errorf("failed to list access keys: %w", err)
+	}
+	for accessKeyId := range accessKeys {
+		if err := accessKeyResource.deleteAccessKey(userName, accessKeyId); err != nil {
+			return fmt.Errorf("failed to delete access key %s: %w", accessKeyId, err)
+		}
+	}
+
+	if err := r.deleteLoginProfile(userName); err != nil {
+		return fmt.Errorf("failed to delete login profile: %w", err)
+	}
+
+	unbindMfaDevice := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		unbindMfaDeviceRequest := &alicloudRamClient.UnbindMFADeviceRequest{
+			UserName: tea.String(userName),
+		}
+
+		if _, err := r.client.UnbindMFADeviceWithOptions(unbindMfaDeviceRequest, runtime); err != nil {
+			if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == ERR_ENTITY_NOT_EXIST_MFA_DEVICE {
+				return nil
+			}
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(unbindMfaDevice, reconnectBackoff); err != nil {
+		return fmt.Errorf("failed to unbind MFA device: %w", err)
+	}
+
+	groupNames, err := listRamUserGroups(r.client, userName)
+	if err != nil {
+		return fmt.Errorf("failed to list groups: %w", err)
+	}
+	for _, groupName := range groupNames {
+		removeUserFromGroupRequest := &alicloudRamClient.RemoveUserFromGroupRequest{
+			UserName:  tea.String(userName),
+			GroupName: tea.String(groupName),
+		}
+
+		removeUserFromGroup := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			if _, err := r.client.RemoveUserFromGroupWithOptions(removeUserFromGroupRequest, runtime); err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(removeUserFromGroup, reconnectBackoff); err != nil {
+			return fmt.Errorf("failed to remove user from group %s: %w", groupName, err)
+		}
+	}
+
+	policies, err := listRamUserPolicies(r.client, userName)
+	if err != nil {
+		return fmt.Errorf("failed to list policies: %w", err)
+	}
+	for _, policy := range policies {
+		detachPolicyFromUserRequest := &alicloudRamClient.DetachPolicyFromUserRequest{
+			UserName:   tea.String(userName),
+			PolicyName: tea.String(policy.PolicyName),
+			PolicyType: tea.String(policy.PolicyType),
+		}
+
+		detachPolicyFromUser := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			if _, err := r.client.DetachPolicyFromUserWithOptions(detachPolicyFromUserRequest, runtime); err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(detachPolicyFromUser, reconnectBackoff); err != nil {
+			return fmt.Errorf("failed to detach policy %s from user: %w", policy.PolicyName, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+data "st-alicloud_alidns_gtm_instances" "production" {
+  name_regex      = "^prod-"
+  package_edition = "ultimate"
+}
+
+resource "st-alicloud_alidns_record" "gtm" {
+  domain_name = "example.com"
+  rr          = "www"
+  type        = "CNAME"
+  value       = data.st-alicloud_alidns_gtm_instances.production.instances[0].cname
+}
+
+output "not_auto_renewed" {
+  value = [
+    for instance in data.st-alicloud_alidns_gtm_instances.production.instances :
+    { id = instance.id, expire_time = instance.expire_time }
+    if instance.renewal_status != "AutoRenewal"
+  ]
+}
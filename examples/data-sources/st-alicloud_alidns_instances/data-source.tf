@@ -0,0 +1,12 @@
+data "st-alicloud_alidns_instances" "expiring" {
+  version_code       = "version_enterprise_basic"
+  expire_within_days = 30
+}
+
+output "expiring_not_auto_renewed" {
+  value = [
+    for instance in data.st-alicloud_alidns_instances.expiring.instances :
+    { id = instance.instance_id, end_time = instance.end_time }
+    if instance.renewal_status != "AutoRenewal"
+  ]
+}
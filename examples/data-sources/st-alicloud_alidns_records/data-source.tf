@@ -0,0 +1,13 @@
+data "st-alicloud_alidns_records" "web" {
+  domain_name = "example.com"
+  rr_regex    = "^www$"
+  type        = "A"
+  status      = "ENABLE"
+}
+
+resource "st-alicloud_alidns_record_weight" "web" {
+  for_each = toset(data.st-alicloud_alidns_records.web.ids)
+
+  id     = each.value
+  weight = 50
+}
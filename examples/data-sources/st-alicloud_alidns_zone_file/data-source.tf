@@ -0,0 +1,7 @@
+data "st-alicloud_alidns_zone_file" "example" {
+  domain_name = "example.com"
+}
+
+output "zone_file" {
+  value = data.st-alicloud_alidns_zone_file.example.content
+}
@@ -0,0 +1,7 @@
+data "st-alicloud_ram_groups" "devops" {
+  user_name = "devopsuser01"
+}
+
+output "group_names" {
+  value = data.st-alicloud_ram_groups.devops.names
+}
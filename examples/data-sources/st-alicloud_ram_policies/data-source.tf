@@ -0,0 +1,9 @@
+data "st-alicloud_ram_policies" "devops" {
+  name_regex  = "^devopsuser01-\\d+$"
+  policy_type = "Custom"
+  user_name   = "devopsuser01"
+}
+
+output "policies" {
+  value = data.st-alicloud_ram_policies.devops.policies
+}
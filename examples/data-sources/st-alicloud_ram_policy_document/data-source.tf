@@ -0,0 +1,29 @@
+data "st-alicloud_ram_policy_document" "oss_read_only" {
+  source_policy_documents = [
+    jsonencode({
+      Version = "1"
+      Statement = [{
+        Effect   = "Allow"
+        Action   = ["ecs:Describe*"]
+        Resource = ["*"]
+      }]
+    })
+  ]
+
+  statement {
+    sid       = "OssRead"
+    effect    = "Allow"
+    actions   = ["oss:GetObject", "oss:ListObjects"]
+    resources = ["acs:oss:*:*:mybucket", "acs:oss:*:*:mybucket/*"]
+
+    condition {
+      operator = "IpAddress"
+      key      = "acs:SourceIp"
+      values   = ["10.0.0.0/8"]
+    }
+  }
+}
+
+output "policy_document" {
+  value = data.st-alicloud_ram_policy_document.oss_read_only.json
+}
@@ -0,0 +1,23 @@
+data "st-alicloud_ram_policy_simulation" "devops" {
+  user_name = "devopsuser01"
+
+  request {
+    action   = "oss:GetObject"
+    resource = "acs:oss:*:*:mybucket/file"
+    context = {
+      "acs:SourceIp" = "10.0.0.1"
+    }
+  }
+
+  request {
+    action   = "ram:CreateUser"
+    resource = "acs:ram:*:*:user/*"
+  }
+}
+
+check "least_privilege" {
+  assert {
+    condition     = data.st-alicloud_ram_policy_simulation.devops.results[1].decision != "allowed"
+    error_message = "devopsuser01 must not be able to create RAM users."
+  }
+}
@@ -0,0 +1,7 @@
+data "st-alicloud_ram_user_effective_policies" "devopsuser01" {
+  user_name = "devopsuser01"
+}
+
+output "statements" {
+  value = data.st-alicloud_ram_user_effective_policies.devopsuser01.statements
+}
@@ -0,0 +1,8 @@
+data "st-alicloud_ram_users" "devops" {
+  name_regex = "^devopsuser"
+  group_name = "devops"
+}
+
+output "user_names" {
+  value = data.st-alicloud_ram_users.devops.names
+}
@@ -0,0 +1,2 @@
+# The custom line is imported by its ID.
+terraform import st-alicloud_alidns_custom_line.office 123456
@@ -0,0 +1,17 @@
+resource "st-alicloud_alidns_custom_line" "office" {
+  domain_name = "example.com"
+  line_name   = "office"
+  ip_segments = [
+    "192.0.2.0/24",
+    "198.51.100.10-198.51.100.20",
+    "203.0.113.5",
+  ]
+}
+
+resource "st-alicloud_alidns_record" "intranet" {
+  domain_name = "example.com"
+  rr          = "intranet"
+  type        = "A"
+  value       = "192.0.2.10"
+  line        = st-alicloud_alidns_custom_line.office.line_code
+}
@@ -0,0 +1,2 @@
+# The domain is imported by the domain name.
+terraform import st-alicloud_alidns_domain.example example.com
@@ -0,0 +1,15 @@
+resource "st-alicloud_alidns_domain" "example" {
+  domain_name   = "example.com"
+  group_id      = "1a2b3c4d"
+  remark        = "Managed by Terraform"
+  dnssec_status = "ON"
+}
+
+# Set the DNS servers and the DS record at the registrar.
+output "dns_servers" {
+  value = st-alicloud_alidns_domain.example.dns_servers
+}
+
+output "ds_record" {
+  value = st-alicloud_alidns_domain.example.ds_record
+}
@@ -0,0 +1,2 @@
+# The access strategy is imported by its ID.
+terraform import st-alicloud_alidns_gtm_access_strategy.example hrsix1a2b3c4d5
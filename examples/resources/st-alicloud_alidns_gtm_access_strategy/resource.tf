@@ -0,0 +1,34 @@
+resource "st-alicloud_alidns_gtm_access_strategy" "example" {
+  instance_id   = st-alicloud_alidns_gtm_instance.example.id
+  strategy_name = "default"
+  strategy_mode = "GEO"
+  lines         = ["default"]
+
+  default_addr_pool_set = {
+    addr_pool_type         = "IPV4"
+    lba_strategy           = "ALL_RR"
+    min_available_addr_num = 1
+
+    addr_pools = [
+      {
+        addr_pool_id = st-alicloud_alidns_gtm_address_pool.primary.addr_pool_id
+      },
+    ]
+  }
+
+  failover_addr_pool_set = {
+    addr_pool_type         = "IPV4"
+    lba_strategy           = "ALL_RR"
+    min_available_addr_num = 1
+
+    addr_pools = [
+      {
+        addr_pool_id = st-alicloud_alidns_gtm_address_pool.backup.addr_pool_id
+      },
+    ]
+  }
+}
+
+output "effective_addr_pool_set" {
+  value = st-alicloud_alidns_gtm_access_strategy.example.effective_addr_pool_group_type
+}
@@ -0,0 +1,3 @@
+# The address pool is imported by its ID. The instance ID is taken from the
+# configuration after the import.
+terraform import st-alicloud_alidns_gtm_address_pool.primary hrsix1a2b3c4d5
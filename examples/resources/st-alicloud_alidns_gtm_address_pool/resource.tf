@@ -0,0 +1,20 @@
+resource "st-alicloud_alidns_gtm_address_pool" "primary" {
+  instance_id  = st-alicloud_alidns_gtm_instance.example.id
+  name         = "primary"
+  type         = "IPV4"
+  lba_strategy = "RATIO"
+
+  addresses = [
+    {
+      address    = "192.0.2.10"
+      mode       = "SMART"
+      lba_weight = 80
+    },
+    {
+      address    = "192.0.2.20"
+      mode       = "SMART"
+      lba_weight = 20
+      remark     = "canary"
+    },
+  ]
+}
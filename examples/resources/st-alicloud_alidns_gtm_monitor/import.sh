@@ -0,0 +1,3 @@
+# The monitor is imported by the ID of its address pool. The instance ID is
+# taken from the configuration after the import.
+terraform import st-alicloud_alidns_gtm_monitor.primary hrsix1a2b3c4d5
@@ -0,0 +1,25 @@
+resource "st-alicloud_alidns_gtm_monitor" "primary" {
+  instance_id      = st-alicloud_alidns_gtm_instance.example.id
+  addr_pool_id     = st-alicloud_alidns_gtm_address_pool.primary.addr_pool_id
+  protocol_type    = "HTTPS"
+  interval         = 60
+  evaluation_count = 3
+  timeout          = 5000
+
+  port = 443
+  host = "www.example.com"
+  path = "/healthz"
+  code = 500
+  sni  = true
+
+  isp_city_nodes = [
+    {
+      isp_code  = "465"
+      city_code = "503"
+    },
+    {
+      isp_code  = "465"
+      city_code = "738"
+    },
+  ]
+}
@@ -0,0 +1,2 @@
+# The domains are imported by the ID of the Alidns instance.
+terraform import st-alicloud_alidns_instance_domains.example dns-cn-abc123def45
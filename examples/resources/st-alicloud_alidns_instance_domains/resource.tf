@@ -0,0 +1,9 @@
+resource "st-alicloud_alidns_instance_domains" "example" {
+  instance_id = "dns-cn-abc123def45"
+
+  domains = [
+    "example.com",
+    "example.net",
+    "example.org",
+  ]
+}
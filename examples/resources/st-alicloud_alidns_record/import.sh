@@ -0,0 +1,2 @@
+# The record is imported by the domain name and the record ID.
+terraform import st-alicloud_alidns_record.www example.com:123456789012345678
@@ -0,0 +1,22 @@
+resource "st-alicloud_alidns_record" "www" {
+  domain_name = "example.com"
+  rr          = "www"
+  type        = "A"
+  value       = "192.0.2.10"
+  ttl         = 600
+  remark      = "Web server"
+}
+
+resource "st-alicloud_alidns_record" "mail" {
+  domain_name = "example.com"
+  rr          = "@"
+  type        = "MX"
+  value       = "mail.example.com"
+  priority    = 10
+}
+
+# The weight of the record is managed by the record weight resource.
+resource "st-alicloud_alidns_record_weight" "www" {
+  id     = st-alicloud_alidns_record.www.record_id
+  weight = 30
+}
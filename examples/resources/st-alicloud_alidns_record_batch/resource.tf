@@ -0,0 +1,30 @@
+locals {
+  hosts = {
+    "node-01" = "192.0.2.11"
+    "node-02" = "192.0.2.12"
+    "node-03" = "192.0.2.13"
+  }
+}
+
+resource "st-alicloud_alidns_record_batch" "nodes" {
+  domain_name = "example.com"
+
+  records = concat(
+    [
+      for rr, ip in local.hosts : {
+        rr    = rr
+        type  = "A"
+        value = ip
+        ttl   = 60
+      }
+    ],
+    [
+      {
+        rr       = "@"
+        type     = "MX"
+        value    = "mail.example.com"
+        priority = 10
+      },
+    ],
+  )
+}
@@ -0,0 +1,4 @@
+# The record set is imported by the domain name, the host record, the record
+# type and optionally the line, which defaults to default.
+terraform import st-alicloud_alidns_weighted_record_set.api example.com:api:A
+terraform import st-alicloud_alidns_weighted_record_set.api example.com:api:A:telecom
@@ -0,0 +1,12 @@
+# Shift the traffic from blue to green by changing the weights in one apply.
+resource "st-alicloud_alidns_weighted_record_set" "api" {
+  domain_name = "example.com"
+  rr          = "api"
+  type        = "A"
+  ttl         = 60
+
+  records = {
+    "192.0.2.10" = 90 # blue
+    "192.0.2.20" = 10 # green
+  }
+}
@@ -0,0 +1,2 @@
+# The zone file is imported by the domain name.
+terraform import st-alicloud_alidns_zone_file.example example.com
@@ -0,0 +1,12 @@
+resource "st-alicloud_alidns_zone_file" "example" {
+  domain_name = "example.com"
+  content     = <<-EOT
+    $ORIGIN example.com.
+    $TTL 600
+    @       IN A     192.0.2.1
+    @       IN MX    10 mail.example.com.
+    @       IN TXT   "v=spf1 include:spf.example.com -all"
+    mail    IN A     192.0.2.2
+    www 300 IN CNAME example.com.
+  EOT
+}
@@ -0,0 +1,2 @@
+# The renewal is imported by <product code>:<instance id>[:<product type>].
+terraform import st-alicloud_bss_instance_renewal.ddoscoo ddoscoo:ddoscoo-sg-example:ddoscoo_intl
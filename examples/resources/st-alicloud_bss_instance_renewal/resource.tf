@@ -0,0 +1,13 @@
+resource "st-alicloud_bss_instance_renewal" "ddoscoo" {
+  product_code   = "ddoscoo"
+  product_type   = "ddoscoo_intl"
+  instance_id    = "ddoscoo-sg-example"
+  renewal_status = "AutoRenewal"
+  renew_period   = 1
+}
+
+resource "st-alicloud_bss_instance_renewal" "adb" {
+  product_code   = "ads"
+  instance_id    = "am-example"
+  renewal_status = "ManualRenewal"
+}
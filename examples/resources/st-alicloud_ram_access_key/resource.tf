@@ -0,0 +1,15 @@
+resource "st-alicloud_ram_access_key" "devopsuser01" {
+  user_name         = "devopsuser01"
+  rotation_days     = 90
+  grace_period_days = 7
+  age_public_key    = "age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p"
+
+  # Keep the old access key during the grace period when it is rotated.
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+output "encrypted_secret" {
+  value = st-alicloud_ram_access_key.devopsuser01.encrypted_secret
+}
@@ -0,0 +1,2 @@
+# There is only one account security setting per account, the ID is ignored.
+terraform import st-alicloud_ram_account_security.this account
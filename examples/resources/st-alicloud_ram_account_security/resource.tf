@@ -0,0 +1,17 @@
+resource "st-alicloud_ram_account_security" "this" {
+  minimum_password_length      = 14
+  require_lowercase_characters = true
+  require_uppercase_characters = true
+  require_numbers              = true
+  require_symbols              = true
+  max_password_age             = 90
+  password_reuse_prevention    = 5
+  max_login_attempts           = 5
+
+  enforce_mfa_for_login = true
+  login_network_masks = [
+    "10.0.0.0/8",
+    "192.168.0.0/16",
+  ]
+  login_session_duration = 6
+}
@@ -0,0 +1,2 @@
+# The group membership is imported by the group name.
+terraform import st-alicloud_ram_group_membership.devops devops
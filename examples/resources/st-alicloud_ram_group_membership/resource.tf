@@ -0,0 +1,7 @@
+resource "st-alicloud_ram_group_membership" "devops" {
+  group_name = "devops"
+  user_names = [
+    "devopsuser01",
+    "devopsuser02",
+  ]
+}
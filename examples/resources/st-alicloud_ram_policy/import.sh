@@ -0,0 +1,6 @@
+# The combined policies are discovered by the principal, policy_name_prefix is
+# default to the user name.
+terraform import st-alicloud_ram_policy.ram_policy user:devopsuser01
+
+# Specify the policy_name_prefix if it is different from the user name.
+terraform import st-alicloud_ram_policy.ram_policy user:devopsuser01:devopsuser01-combined
@@ -1,4 +1,6 @@
 resource "st-alicloud_ram_policy" "ram_policy" {
-  attached_policies = ["AliyunECSFullAccess", "AliyunRAMFullAccess", "AliyunOSSFullAccess", "AliyunOTSFullAccess", ]
-  user_name         = "devopsuser01"
+  attached_policies  = ["AliyunECSFullAccess", "AliyunRAMFullAccess", "AliyunOSSFullAccess", "AliyunOTSFullAccess", ]
+  user_name          = "devopsuser01"
+  policy_name_prefix = "devopsuser01-combined"
+  description        = "Combined policies of devopsuser01, managed by Terraform."
 }
@@ -0,0 +1,2 @@
+# The role is imported by the role name.
+terraform import st-alicloud_ram_role.automation cross-account-automation
@@ -0,0 +1,22 @@
+resource "st-alicloud_ram_role" "automation" {
+  role_name = "cross-account-automation"
+  assume_role_policy_document = jsonencode({
+    Version = "1"
+    Statement = [{
+      Effect = "Allow"
+      Action = "sts:AssumeRole"
+      Principal = {
+        RAM = ["acs:ram::123456789012****:root"]
+      }
+    }]
+  })
+  description          = "Assumed by the automation account."
+  max_session_duration = 7200
+
+  system_policies = [
+    "AliyunDNSFullAccess",
+  ]
+  custom_policies = [
+    "automation-1",
+  ]
+}
@@ -0,0 +1,3 @@
+# The user is imported by the user name. The login password can not be read
+# back, so it is reset to the configured password on the next apply.
+terraform import st-alicloud_ram_user.developer developer
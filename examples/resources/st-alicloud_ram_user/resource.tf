@@ -0,0 +1,17 @@
+resource "st-alicloud_ram_user" "developer" {
+  user_name    = "developer"
+  display_name = "Developer"
+  email        = "developer@example.com"
+  comments     = "Managed by Terraform."
+
+  login_profile {
+    password                = var.developer_initial_password
+    password_reset_required = true
+    mfa_bind_required       = true
+  }
+}
+
+variable "developer_initial_password" {
+  type      = string
+  sensitive = true
+}